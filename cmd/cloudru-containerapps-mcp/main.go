@@ -1,8 +1,11 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	// Schedule time zones must resolve on systems without a time zone database, e.g. Windows
+	_ "time/tzdata"
 
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/application"
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
@@ -14,7 +17,9 @@ import (
 func main() {
 	// Create infrastructure layer
 	dockerInfrastructure := application.NewDockerApplication()
-	containerAppsService := application.NewContainerAppsApplication()
+	guardrailsService := application.NewGuardrailsApplication()
+	containerAppsService := application.NewContainerAppsApplication(guardrailsService)
+	schedulerService := application.NewSchedulerApplication(containerAppsService)
 
 	// Create application layer
 	descriptionService := application.NewDescriptionApplication()
@@ -24,7 +29,7 @@ func main() {
 	log.Println(descriptionService.GetDescription())
 
 	// Create presentation layer
-	mcpServer := presentation.NewMCPServer(descriptionService, dockerInfrastructure, containerAppsService, containerAppsService.(domain.DockerRegistryService))
+	mcpServer := presentation.NewMCPServer(descriptionService, dockerInfrastructure, containerAppsService, containerAppsService.(domain.DockerRegistryService), schedulerService)
 
 	// Create a new MCP server
 	s := server.NewMCPServer(
@@ -41,11 +46,32 @@ func main() {
 	mcpServer.RegisterGetListContainerAppsTool(s)
 	mcpServer.RegisterGetContainerAppTool(s)
 	mcpServer.RegisterCreateContainerAppTool(s)
+	mcpServer.RegisterUpdateContainerAppTool(s)
+	mcpServer.RegisterSetContainerAppAutoDeploymentsTool(s)
+	mcpServer.RegisterCloneContainerAppTool(s)
+	mcpServer.RegisterExportContainerAppTool(s)
 	mcpServer.RegisterDeleteContainerAppTool(s)
 	mcpServer.RegisterStartContainerAppTool(s)
 	mcpServer.RegisterStopContainerAppTool(s)
+	mcpServer.RegisterRestartContainerAppTool(s)
+	mcpServer.RegisterBulkContainerAppsTool(s)
+	mcpServer.RegisterGetOperationTool(s)
+	mcpServer.RegisterListSchedulesTool(s)
+	mcpServer.RegisterAddScheduleTool(s)
+	mcpServer.RegisterRemoveScheduleTool(s)
+	mcpServer.RegisterListCustomDomainsTool(s)
+	mcpServer.RegisterAttachCustomDomainTool(s)
+	mcpServer.RegisterDetachCustomDomainTool(s)
 	mcpServer.RegisterGetListDockerRegistriesTool(s)
 	mcpServer.RegisterCreateDockerRegistryTool(s)
+	mcpServer.RegisterApplyDockerRegistryRetentionTool(s)
+	mcpServer.RegisterDeleteDockerRegistryTool(s)
+	mcpServer.RegisterListRepositoriesTool(s)
+	mcpServer.RegisterListImageTagsTool(s)
+	mcpServer.RegisterDeleteImageTool(s)
+
+	// Start and stop Container Apps on schedules while the server is running
+	go schedulerService.Run(context.Background())
 
 	// Start the server
 	if err := server.ServeStdio(s); err != nil {
@@ -16,7 +16,7 @@ func main() {
 }
 
 func getListDockerRegistries(cfg *config.Config) {
-	ca := application.NewContainerAppsApplication()
+	ca := application.NewContainerAppsApplication(application.NewGuardrailsApplication())
 
 	log.Println("Testing GetListDockerRegistries...")
 	registries, err := ca.(domain.DockerRegistryService).GetListDockerRegistries(
@@ -34,7 +34,7 @@ func getListDockerRegistries(cfg *config.Config) {
 }
 
 func createDockerRegistry(cfg *config.Config, name string, isPublic bool) {
-	ca := application.NewContainerAppsApplication()
+	ca := application.NewContainerAppsApplication(application.NewGuardrailsApplication())
 
 	log.Printf("Testing CreateDockerRegistry with name: %s, isPublic: %v...", name, isPublic)
 	registry, err := ca.(domain.DockerRegistryService).CreateDockerRegistry(
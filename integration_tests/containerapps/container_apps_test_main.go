@@ -22,7 +22,7 @@ func main() {
 }
 
 func getListContainerApps(cfg *config.Config) {
-	ca := application.NewContainerAppsApplication()
+	ca := application.NewContainerAppsApplication(application.NewGuardrailsApplication())
 
 	log.Println("Testing GetListContainerApps...")
 	cas, err := ca.GetListContainerApps(
@@ -40,7 +40,7 @@ func getListContainerApps(cfg *config.Config) {
 }
 
 func getContainerApp(cfg *config.Config, name string) {
-	ca := application.NewContainerAppsApplication()
+	ca := application.NewContainerAppsApplication(application.NewGuardrailsApplication())
 
 	log.Println("Testing GetContainerApp...")
 	cas_, err := ca.GetContainerApp(
@@ -58,7 +58,7 @@ func getContainerApp(cfg *config.Config, name string) {
 }
 
 func createContainerApp(cfg *config.Config, name, image string, port int) {
-	ca := application.NewContainerAppsApplication()
+	ca := application.NewContainerAppsApplication(application.NewGuardrailsApplication())
 
 	// Test CreateContainerApp
 	containerApp, err := ca.CreateContainerApp(
@@ -66,6 +66,7 @@ func createContainerApp(cfg *config.Config, name, image string, port int) {
 		name,
 		port,
 		image,
+		domain.ContainerAppOptions{},
 		domain.Credentials{
 			KeyID:     cfg.KeyID,
 			KeySecret: cfg.KeySecret,
@@ -1,22 +1,29 @@
 package application
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
 )
 
 // ContainerAppsApplication implements the ContainerAppsService and DockerRegistryService interfaces
-type ContainerAppsApplication struct{}
+type ContainerAppsApplication struct {
+	guardrails domain.GuardrailsService
+}
 
-// NewContainerAppsApplication creates a new ContainerAppsApplication
-func NewContainerAppsApplication() domain.ContainerAppsService {
-	return &ContainerAppsApplication{}
+// NewContainerAppsApplication creates a new ContainerAppsApplication.
+// Destructive actions are checked and recorded by the guardrails service.
+func NewContainerAppsApplication(guardrails domain.GuardrailsService) domain.ContainerAppsService {
+	return &ContainerAppsApplication{
+		guardrails: guardrails,
+	}
 }
 
 // GetListContainerApps gets a list of ContainerApps from Cloud.ru API
@@ -102,7 +109,7 @@ func (c *ContainerAppsApplication) GetContainerApp(projectID string, containerAp
 	log.Printf("GetContainerApp response - Status: %d, Body length: %d, Body: %s", resp.StatusCode, len(body), string(body))
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, &domain.APIError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	// Check if body is empty
@@ -120,95 +127,267 @@ func (c *ContainerAppsApplication) GetContainerApp(projectID string, containerAp
 }
 
 // CreateContainerApp creates a new ContainerApp in Cloud.ru
-func (c *ContainerAppsApplication) CreateContainerApp(projectID string, containerAppName string, containerAppPort int, containerAppImage string, credentials domain.Credentials) (*domain.ContainerApp, error) {
-	// Get access token using KEY_ID and KEY_SECRET
-	token, err := c.getAccessToken(credentials.KeyID, credentials.KeySecret)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get access token: %w", err)
-	}
-
-	// Prepare the request payload
-	payload := map[string]interface{}{
-		"name":        containerAppName,
-		"projectId":   projectID,
-		"description": fmt.Sprintf("Container App %s created via MCP", containerAppName),
-		"configuration": map[string]interface{}{
-			"ingress": map[string]interface{}{
-				"publiclyAccessible": true,
+func (c *ContainerAppsApplication) CreateContainerApp(projectID string, containerAppName string, containerAppPort int, containerAppImage string, options domain.ContainerAppOptions, credentials domain.Credentials) (*domain.ContainerApp, error) {
+	// Container Apps are publicly accessible unless the options say otherwise
+	spec := domain.ContainerApp{
+		ProjectID:   projectID,
+		Name:        containerAppName,
+		Description: fmt.Sprintf("Container App %s created via MCP", containerAppName),
+		Configuration: domain.ContainerAppConfiguration{
+			Ingress: domain.Ingress{
+				PubliclyAccessible: true,
 			},
 		},
-		"template": map[string]interface{}{
-			"containers": []map[string]interface{}{
+		Template: domain.ContainerAppTemplate{
+			Containers: []domain.Container{
 				{
-					"name":          containerAppName,
-					"image":         containerAppImage,
-					"containerPort": containerAppPort,
-					"env": []map[string]string{
+					Name:          containerAppName,
+					Image:         containerAppImage,
+					ContainerPort: containerAppPort,
+					Env: []domain.EnvVar{
 						{
-							"name":  "CONTAINERAPP_NAME",
-							"value": containerAppName,
+							Name:  "CONTAINERAPP_NAME",
+							Value: containerAppName,
 						},
 					},
 				},
 			},
 		},
 	}
+	if err := options.ApplyTo(&spec); err != nil {
+		return nil, fmt.Errorf("invalid container app options: %w", err)
+	}
 
-	// Convert payload to JSON
-	jsonPayload, err := json.Marshal(payload)
+	return c.createContainerApp(spec, credentials)
+}
+
+// createContainerApp creates a ContainerApp in Cloud.ru from a complete specification
+func (c *ContainerAppsApplication) createContainerApp(spec domain.ContainerApp, credentials domain.Credentials) (*domain.ContainerApp, error) {
+	// Get access token using KEY_ID and KEY_SECRET
+	token, err := c.getAccessToken(credentials.KeyID, credentials.KeySecret)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	// Prepare the request payload
+	payload := map[string]interface{}{
+		"name":          spec.Name,
+		"projectId":     spec.ProjectID,
+		"description":   spec.Description,
+		"configuration": configurationPayload(spec.Configuration),
+		"template":      templatePayload(spec.Template),
+	}
+	if len(spec.Labels) > 0 {
+		payload["labels"] = spec.Labels
 	}
 
 	// Make request to ContainerApps API
 	url := "https://containers.api.cloud.ru/v2/containers/"
-	req, err := http.NewRequest("POST", url, strings.NewReader(string(jsonPayload)))
+	statusCode, body, err := c.doAPIRequest("POST", url, token, payload)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Content-Type", "application/json")
+	// Log the response for debugging
+	log.Printf("CreateContainerApp response - Status: %d, Body length: %d, Body: %s", statusCode, len(body), string(body))
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	if statusCode != http.StatusCreated && statusCode != http.StatusOK {
+		return nil, &domain.APIError{StatusCode: statusCode, Body: string(body)}
+	}
+
+	// Check if body is empty
+	if len(body) == 0 {
+		return nil, fmt.Errorf("API returned empty response body with status %d", statusCode)
+	}
+
+	// Parse response
+	var containerApp domain.ContainerApp
+	if err := json.Unmarshal(body, &containerApp); err != nil {
+		return nil, fmt.Errorf("failed to parse containerapp response: %w body length: %d body: %s", err, len(body), string(body))
+	}
+	// The API may answer with the operation only, then the specification we sent is returned
+	if containerApp.Name == "" {
+		containerApp = spec
+	}
+	containerApp.OperationID = operationIDFromResponse(body)
+
+	return &containerApp, nil
+}
+
+// UpdateContainerApp applies options to an existing ContainerApp in Cloud.ru.
+// Settings not covered by the options are taken from the current state of the ContainerApp.
+func (c *ContainerAppsApplication) UpdateContainerApp(projectID string, containerAppName string, options domain.ContainerAppOptions, credentials domain.Credentials) (*domain.ContainerApp, error) {
+	if err := options.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid container app options: %w", err)
+	}
+
+	containerApp, err := c.GetContainerApp(projectID, containerAppName, credentials)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+		return nil, fmt.Errorf("failed to get current container app state: %w", err)
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	if err := options.ApplyTo(containerApp); err != nil {
+		return nil, fmt.Errorf("invalid container app options: %w", err)
+	}
+
+	return c.updateContainerApp(projectID, containerAppName, containerApp, credentials)
+}
+
+// updateContainerApp replaces the settings of an existing ContainerApp in Cloud.ru with a complete specification
+func (c *ContainerAppsApplication) updateContainerApp(projectID string, containerAppName string, containerApp *domain.ContainerApp, credentials domain.Credentials) (*domain.ContainerApp, error) {
+	// Get access token using KEY_ID and KEY_SECRET
+	token, err := c.getAccessToken(credentials.KeyID, credentials.KeySecret)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	// Prepare the request payload from the updated state
+	payload := map[string]interface{}{
+		"description":   containerApp.Description,
+		"labels":        labelsPayload(containerApp.Labels),
+		"configuration": configurationPayload(containerApp.Configuration),
+		"template":      containerApp.Template,
+	}
+
+	// Make PATCH request to ContainerApps API
+	url := fmt.Sprintf("https://containers.api.cloud.ru/v2/containers/%s?projectId=%s", containerAppName, projectID)
+	statusCode, body, err := c.doAPIRequest("PATCH", url, token, payload)
+	if err != nil {
+		return nil, err
 	}
 
 	// Log the response for debugging
-	log.Printf("CreateContainerApp response - Status: %d, Body length: %d, Body: %s", resp.StatusCode, len(body), string(body))
+	log.Printf("UpdateContainerApp response - Status: %d, Body length: %d, Body: %s", statusCode, len(body), string(body))
 
-	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	if statusCode != http.StatusOK && statusCode != http.StatusAccepted {
+		return nil, &domain.APIError{StatusCode: statusCode, Body: string(body)}
 	}
 
-	// Check if body is empty
+	// The API may accept the update without returning the container app, so fall back to the state we sent
 	if len(body) == 0 {
-		return nil, fmt.Errorf("API returned empty response body with status %d", resp.StatusCode)
+		return containerApp, nil
 	}
 
-	// Parse response
-	var containerApp domain.ContainerApp
-	if err := json.Unmarshal(body, &containerApp); err != nil {
+	var updatedContainerApp domain.ContainerApp
+	if err := json.Unmarshal(body, &updatedContainerApp); err != nil {
 		return nil, fmt.Errorf("failed to parse containerapp response: %w body length: %d body: %s", err, len(body), string(body))
 	}
+	// The API may answer with the operation only, then the state we sent is returned
+	if updatedContainerApp.Name == "" {
+		updatedContainerApp = *containerApp
+	}
+	updatedContainerApp.OperationID = operationIDFromResponse(body)
 
-	return &containerApp, nil
+	return &updatedContainerApp, nil
 }
 
-// DeleteContainerApp deletes a ContainerApp from Cloud.ru
-func (c *ContainerAppsApplication) DeleteContainerApp(projectID string, containerAppName string, credentials domain.Credentials) error {
+// templatePayload converts the template of a new ContainerApp into the request payload format.
+// Only the settings that were set are included so the API applies its defaults for the rest.
+func templatePayload(template domain.ContainerAppTemplate) map[string]interface{} {
+	containers := []map[string]interface{}{}
+	for _, container := range template.Containers {
+		containers = append(containers, containerPayload(container))
+	}
+
+	payload := map[string]interface{}{
+		"containers": containers,
+	}
+	if template.Timeout != "" {
+		payload["timeout"] = template.Timeout
+	}
+	if template.IdleTimeout != "" {
+		payload["idleTimeout"] = template.IdleTimeout
+	}
+	if template.Protocol != "" {
+		payload["protocol"] = template.Protocol
+	}
+	if template.Scaling.MaxInstanceCount > 0 {
+		payload["scaling"] = template.Scaling
+	}
+	if len(template.InitContainers) > 0 {
+		initContainers := []map[string]interface{}{}
+		for _, container := range template.InitContainers {
+			initContainers = append(initContainers, containerPayload(container))
+		}
+		payload["initContainers"] = initContainers
+	}
+	if len(template.Volumes) > 0 {
+		payload["volumes"] = template.Volumes
+	}
+
+	return payload
+}
+
+// containerPayload converts a container into the request payload format
+func containerPayload(container domain.Container) map[string]interface{} {
+	env := container.Env
+	if env == nil {
+		env = []domain.EnvVar{}
+	}
+
+	payload := map[string]interface{}{
+		"name":  container.Name,
+		"image": container.Image,
+		"env":   env,
+	}
+	if container.ContainerPort != 0 {
+		payload["containerPort"] = container.ContainerPort
+	}
+	if container.Resources.CPU != "" || container.Resources.Memory != "" {
+		payload["resources"] = container.Resources
+	}
+	if len(container.Command) > 0 {
+		payload["command"] = container.Command
+	}
+	if len(container.Args) > 0 {
+		payload["args"] = container.Args
+	}
+	if len(container.VolumeMounts) > 0 {
+		payload["volumeMounts"] = container.VolumeMounts
+	}
+
+	return payload
+}
+
+// configurationPayload converts the configuration of a ContainerApp into the request payload format
+func configurationPayload(configuration domain.ContainerAppConfiguration) map[string]interface{} {
+	return map[string]interface{}{
+		"ingress":         ingressPayload(configuration.Ingress),
+		"autoDeployments": configuration.AutoDeployments,
+		"privileged":      configuration.Privileged,
+	}
+}
+
+// ingressPayload converts ingress settings into the request payload format, omitting server-assigned URIs
+func ingressPayload(ingress domain.Ingress) map[string]interface{} {
+	portMappings := ingress.AdditionalPortMappings
+	if portMappings == nil {
+		portMappings = []domain.PortMapping{}
+	}
+
+	payload := map[string]interface{}{
+		"publiclyAccessible":     ingress.PubliclyAccessible,
+		"additionalPortMappings": portMappings,
+	}
+	if ingress.ContainerName != "" {
+		payload["containerName"] = ingress.ContainerName
+	}
+
+	return payload
+}
+
+// DeleteContainerApp deletes a ContainerApp from Cloud.ru unless it is protected.
+// It returns the ID of the platform operation if the API reports one.
+func (c *ContainerAppsApplication) DeleteContainerApp(projectID string, containerAppName string, credentials domain.Credentials) (string, error) {
+	return c.guardDestructiveAction(domain.DestructiveActionDelete, projectID, containerAppName, credentials, c.deleteContainerApp)
+}
+
+// deleteContainerApp deletes a ContainerApp from Cloud.ru
+func (c *ContainerAppsApplication) deleteContainerApp(projectID string, containerAppName string, credentials domain.Credentials) (string, error) {
 	// Get access token using KEY_ID and KEY_SECRET
 	token, err := c.getAccessToken(credentials.KeyID, credentials.KeySecret)
 	if err != nil {
-		return fmt.Errorf("failed to get access token: %w", err)
+		return "", fmt.Errorf("failed to get access token: %w", err)
 	}
 
 	// Make DELETE request to ContainerApps API
@@ -216,7 +395,7 @@ func (c *ContainerAppsApplication) DeleteContainerApp(projectID string, containe
 	url := fmt.Sprintf("https://containers.api.cloud.ru/v2/containers/%s?projectId=%s", containerAppName, projectID)
 	req, err := http.NewRequest("DELETE", url, nil)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Authorization", "Bearer "+token)
@@ -225,30 +404,31 @@ func (c *ContainerAppsApplication) DeleteContainerApp(projectID string, containe
 	client := &http.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to make request: %w", err)
+		return "", fmt.Errorf("failed to make request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return "", fmt.Errorf("failed to read response body: %w", err)
 	}
 
 	// According to the API documentation, a successful deletion should return 204 No Content
-	// but we'll accept 200 OK as well
-	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	// but we'll accept 200 OK and 202 Accepted for an asynchronous deletion as well
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		return "", fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
 	}
 
-	return nil
+	return operationIDFromResponse(body), nil
 }
 
-// StartContainerApp starts a ContainerApp in Cloud.ru
-func (c *ContainerAppsApplication) StartContainerApp(projectID string, containerAppName string, credentials domain.Credentials) error {
+// StartContainerApp starts a ContainerApp in Cloud.ru.
+// It returns the ID of the platform operation if the API reports one.
+func (c *ContainerAppsApplication) StartContainerApp(projectID string, containerAppName string, credentials domain.Credentials) (string, error) {
 	// Get access token using KEY_ID and KEY_SECRET
 	token, err := c.getAccessToken(credentials.KeyID, credentials.KeySecret)
 	if err != nil {
-		return fmt.Errorf("failed to get access token: %w", err)
+		return "", fmt.Errorf("failed to get access token: %w", err)
 	}
 
 	// Make POST request to ContainerApps API to start the container app
@@ -256,7 +436,7 @@ func (c *ContainerAppsApplication) StartContainerApp(projectID string, container
 	url := fmt.Sprintf("https://containers.api.cloud.ru/v2/containers/%s:start?projectId=%s", containerAppName, projectID)
 	req, err := http.NewRequest("POST", url, nil)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Authorization", "Bearer "+token)
@@ -265,29 +445,35 @@ func (c *ContainerAppsApplication) StartContainerApp(projectID string, container
 	client := &http.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to make request: %w", err)
+		return "", fmt.Errorf("failed to make request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return "", fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	// According to the API documentation, a successful start should return 200 OK
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	// According to the API documentation, a successful start should return 200 OK, an asynchronous one may return 202 Accepted
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		return "", fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
 	}
 
-	return nil
+	return operationIDFromResponse(body), nil
+}
+
+// StopContainerApp stops a ContainerApp in Cloud.ru unless it is protected.
+// It returns the ID of the platform operation if the API reports one.
+func (c *ContainerAppsApplication) StopContainerApp(projectID string, containerAppName string, credentials domain.Credentials) (string, error) {
+	return c.guardDestructiveAction(domain.DestructiveActionStop, projectID, containerAppName, credentials, c.stopContainerApp)
 }
 
-// StopContainerApp stops a ContainerApp in Cloud.ru
-func (c *ContainerAppsApplication) StopContainerApp(projectID string, containerAppName string, credentials domain.Credentials) error {
+// stopContainerApp stops a ContainerApp in Cloud.ru
+func (c *ContainerAppsApplication) stopContainerApp(projectID string, containerAppName string, credentials domain.Credentials) (string, error) {
 	// Get access token using KEY_ID and KEY_SECRET
 	token, err := c.getAccessToken(credentials.KeyID, credentials.KeySecret)
 	if err != nil {
-		return fmt.Errorf("failed to get access token: %w", err)
+		return "", fmt.Errorf("failed to get access token: %w", err)
 	}
 
 	// Make POST request to ContainerApps API to stop the container app
@@ -295,7 +481,7 @@ func (c *ContainerAppsApplication) StopContainerApp(projectID string, containerA
 	url := fmt.Sprintf("https://containers.api.cloud.ru/v2/containers/%s:stop?projectId=%s", containerAppName, projectID)
 	req, err := http.NewRequest("POST", url, nil)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Authorization", "Bearer "+token)
@@ -304,21 +490,89 @@ func (c *ContainerAppsApplication) StopContainerApp(projectID string, containerA
 	client := &http.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to make request: %w", err)
+		return "", fmt.Errorf("failed to make request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return "", fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	// According to the API documentation, a successful stop should return 200 OK
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	// According to the API documentation, a successful stop should return 200 OK, an asynchronous one may return 202 Accepted
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		return "", fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	return operationIDFromResponse(body), nil
+}
+
+// guardDestructiveAction refuses the action for protected ContainerApps, otherwise performs it
+// and records it in the audit log together with the last known specification of the ContainerApp
+func (c *ContainerAppsApplication) guardDestructiveAction(action string, projectID string, containerAppName string, credentials domain.Credentials, perform func(projectID string, containerAppName string, credentials domain.Credentials) (string, error)) (string, error) {
+	if err := c.guardrails.CheckDestructiveAction(action, containerAppName); err != nil {
+		return "", err
+	}
+
+	record := domain.AuditRecord{
+		Action:           action,
+		ProjectID:        projectID,
+		ContainerAppName: containerAppName,
+	}
+
+	// The specification is best effort: if it can't be read, the action itself will most likely fail too
+	lastKnownSpec, err := c.GetContainerApp(projectID, containerAppName, credentials)
+	if err != nil {
+		log.Printf("guardDestructiveAction - failed to get last known spec of %s: %v", containerAppName, err)
+	}
+	record.LastKnownSpec = lastKnownSpec
+
+	operationID, actionErr := perform(projectID, containerAppName, credentials)
+	record.Time = time.Now().UTC()
+	record.OperationID = operationID
+	if actionErr != nil {
+		record.Error = actionErr.Error()
+	}
+
+	if err := c.guardrails.RecordDestructiveAction(record); err != nil {
+		log.Printf("guardDestructiveAction - failed to record %s of %s: %v", action, containerAppName, err)
+	}
+
+	return operationID, actionErr
+}
+
+// doAPIRequest makes an authorized JSON request to Cloud.ru API and returns the response status code and body
+func (c *ContainerAppsApplication) doAPIRequest(method string, url string, token string, payload interface{}) (int, []byte, error) {
+	var requestBody io.Reader
+	if payload != nil {
+		jsonPayload, err := json.Marshal(payload)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to marshal payload: %w", err)
+		}
+		requestBody = bytes.NewReader(jsonPayload)
+	}
+
+	req, err := http.NewRequest(method, url, requestBody)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	return nil
+	return resp.StatusCode, body, nil
 }
 
 // getAccessToken gets an access token using KEY_ID and KEY_SECRET
@@ -494,3 +748,11 @@ func (c *ContainerAppsApplication) CreateDockerRegistry(projectID string, regist
 
 	return &registry, nil
 }
+
+// labelsPayload returns the labels for an update request, an empty object removes all labels
+func labelsPayload(labels map[string]string) map[string]string {
+	if labels == nil {
+		return map[string]string{}
+	}
+	return labels
+}
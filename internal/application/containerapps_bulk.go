@@ -0,0 +1,121 @@
+package application
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+)
+
+// maxBulkConcurrency limits how many Container Apps are changed at the same time by a bulk action
+const maxBulkConcurrency = 10
+
+// BulkContainerAppsAction starts, stops or deletes every ContainerApp matching the filter.
+// In dry run mode nothing is changed and the result only lists the Container Apps that would be affected.
+// Bulk delete only runs when confirmedNames lists exactly the Container Apps matching the filter.
+func (c *ContainerAppsApplication) BulkContainerAppsAction(projectID string, action string, filter *domain.ContainerAppFilter, dryRun bool, concurrency int, confirmedNames []string, credentials domain.Credentials) (*domain.BulkResult, error) {
+	var actionFunc func(projectID string, containerAppName string, credentials domain.Credentials) (string, error)
+	switch action {
+	case domain.BulkActionStart:
+		actionFunc = c.StartContainerApp
+	case domain.BulkActionStop:
+		actionFunc = c.StopContainerApp
+	case domain.BulkActionDelete:
+		actionFunc = c.DeleteContainerApp
+	default:
+		return nil, fmt.Errorf("bulk action must be %s, %s or %s", domain.BulkActionStart, domain.BulkActionStop, domain.BulkActionDelete)
+	}
+
+	// An empty selector would match the whole project, which is never what a bulk action should do by accident
+	if filter == nil || filter.IsEmpty() {
+		return nil, fmt.Errorf("bulk %s requires a selector, for example a name pattern", action)
+	}
+	if action != domain.BulkActionStart && !filter.SelectsByNameOrLabels() {
+		return nil, fmt.Errorf("bulk %s requires a name pattern, a name regex or a label selector", action)
+	}
+
+	if concurrency < 1 || concurrency > maxBulkConcurrency {
+		return nil, fmt.Errorf("concurrency must be between 1 and %d", maxBulkConcurrency)
+	}
+
+	containerApps, err := c.GetListContainerApps(projectID, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get container apps: %w", err)
+	}
+	containerApps = filter.Filter(containerApps)
+	sort.Slice(containerApps, func(i, j int) bool {
+		return containerApps[i].Name < containerApps[j].Name
+	})
+
+	result := &domain.BulkResult{
+		Action: action,
+		DryRun: dryRun,
+		Items:  make([]domain.BulkItemResult, len(containerApps)),
+	}
+	for i, containerApp := range containerApps {
+		result.Items[i] = domain.BulkItemResult{
+			Name:   containerApp.Name,
+			Status: containerApp.Status,
+		}
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	if action == domain.BulkActionDelete {
+		if err := checkConfirmedNames(result.Items, confirmedNames); err != nil {
+			return nil, err
+		}
+	}
+
+	// Each worker writes only its own item, so the results need no extra locking
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, concurrency)
+	for i := range result.Items {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(item *domain.BulkItemResult) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			operationID, err := actionFunc(projectID, item.Name, credentials)
+			item.OperationID = operationID
+			if err != nil {
+				item.Error = err.Error()
+			}
+		}(&result.Items[i])
+	}
+	wg.Wait()
+
+	return result, nil
+}
+
+// checkConfirmedNames makes sure the confirmed names are exactly the Container Apps the action would be applied to,
+// so the set can't silently change between the dry run and the real run
+func checkConfirmedNames(items []domain.BulkItemResult, confirmedNames []string) error {
+	confirmed := map[string]bool{}
+	for _, name := range confirmedNames {
+		confirmed[name] = true
+	}
+
+	missing := []string{}
+	for _, item := range items {
+		if !confirmed[item.Name] {
+			missing = append(missing, item.Name)
+		}
+		delete(confirmed, item.Name)
+	}
+
+	unexpected := []string{}
+	for name := range confirmed {
+		unexpected = append(unexpected, name)
+	}
+	sort.Strings(unexpected)
+
+	if len(missing) > 0 || len(unexpected) > 0 {
+		return fmt.Errorf("confirmed names don't match the container apps to delete, nothing was deleted (not confirmed: [%s], confirmed but not matched: [%s])", strings.Join(missing, ", "), strings.Join(unexpected, ", "))
+	}
+	return nil
+}
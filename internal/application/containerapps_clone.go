@@ -0,0 +1,130 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+)
+
+// CloneContainerApp creates a copy of a ContainerApp under a new name, optionally in another project.
+// Secret environment variables are only carried over when options.CopySecrets is set.
+func (c *ContainerAppsApplication) CloneContainerApp(projectID string, containerAppName string, options domain.CloneOptions, credentials domain.Credentials) (*domain.CloneResult, error) {
+	if err := domain.ValidateContainerAppName(options.TargetName); err != nil {
+		return nil, fmt.Errorf("invalid target container app name: %w", err)
+	}
+
+	targetProjectID := options.TargetProjectID
+	if targetProjectID == "" {
+		targetProjectID = projectID
+	}
+	if targetProjectID == projectID && options.TargetName == containerAppName {
+		return nil, fmt.Errorf("target container app must differ from the source by name or project")
+	}
+
+	source, err := c.GetContainerApp(projectID, containerAppName, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source container app: %w", err)
+	}
+
+	spec, skippedSecretEnv := cloneSpec(*source, targetProjectID, options)
+	if err := domain.ValidateContainerAppSpec(&spec); err != nil {
+		return nil, fmt.Errorf("invalid copy of container app %s: %w", containerAppName, err)
+	}
+
+	containerApp, err := c.createContainerApp(spec, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create container app %s as a copy of %s: %w", options.TargetName, containerAppName, err)
+	}
+
+	return &domain.CloneResult{
+		ContainerApp:     containerApp,
+		SkippedSecretEnv: skippedSecretEnv,
+	}, nil
+}
+
+// cloneSpec builds the specification of a copy of the source ContainerApp without server-assigned fields
+func cloneSpec(source domain.ContainerApp, targetProjectID string, options domain.CloneOptions) (domain.ContainerApp, []string) {
+	spec := domain.ContainerApp{
+		ProjectID:     targetProjectID,
+		Name:          options.TargetName,
+		Description:   source.Description,
+		Labels:        source.Labels,
+		Configuration: source.Configuration,
+		Template:      source.Template,
+	}
+	spec.Configuration.Ingress.PublicUri = ""
+	spec.Configuration.Ingress.InternalUri = ""
+	// The ingress container follows the main container if it is renamed below
+	if spec.Configuration.Ingress.ContainerName == source.Name {
+		spec.Configuration.Ingress.ContainerName = options.TargetName
+	}
+
+	skippedSecretEnv := []string{}
+
+	spec.Template.Containers = make([]domain.Container, len(source.Template.Containers))
+	for i, container := range source.Template.Containers {
+		// The main container is named after the container app by convention, so it follows the new name
+		if container.Name == source.Name {
+			container.Name = options.TargetName
+		}
+		if i == 0 && options.Image != "" {
+			container.Image = options.Image
+		}
+
+		var env []domain.EnvVar
+		env, skippedSecretEnv = cloneEnv(container.Name, container.Env, options.CopySecrets, skippedSecretEnv)
+		for j := range env {
+			if env[j].Name == "CONTAINERAPP_NAME" && env[j].Value == source.Name {
+				env[j].Value = options.TargetName
+			}
+		}
+		// Explicit overrides are always applied, including secret ones
+		if i == 0 {
+			env = mergeEnv(env, options.Env)
+		}
+		container.Env = env
+
+		spec.Template.Containers[i] = container
+	}
+
+	spec.Template.InitContainers = make([]domain.Container, len(source.Template.InitContainers))
+	for i, container := range source.Template.InitContainers {
+		container.Env, skippedSecretEnv = cloneEnv(container.Name, container.Env, options.CopySecrets, skippedSecretEnv)
+		spec.Template.InitContainers[i] = container
+	}
+
+	return spec, skippedSecretEnv
+}
+
+// cloneEnv copies environment variables of a container, leaving out secrets unless copySecrets is set.
+// Skipped variables are appended to skipped as container/variable names.
+func cloneEnv(containerName string, env []domain.EnvVar, copySecrets bool, skipped []string) ([]domain.EnvVar, []string) {
+	result := []domain.EnvVar{}
+	for _, envVar := range env {
+		if strings.EqualFold(envVar.Type, domain.EnvVarTypeSecret) && !copySecrets {
+			skipped = append(skipped, containerName+"/"+envVar.Name)
+			continue
+		}
+		result = append(result, envVar)
+	}
+	return result, skipped
+}
+
+// mergeEnv overrides environment variables by name and appends the ones that are not set yet
+func mergeEnv(env []domain.EnvVar, overrides []domain.EnvVar) []domain.EnvVar {
+	for _, override := range overrides {
+		overridden := false
+		for i := range env {
+			if env[i].Name == override.Name {
+				env[i] = override
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			env = append(env, override)
+		}
+	}
+	return env
+}
@@ -0,0 +1,37 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+)
+
+func TestCloneSpecRenamesIngressContainer(t *testing.T) {
+	source := domain.ContainerApp{
+		Name: "api",
+		Configuration: domain.ContainerAppConfiguration{
+			Ingress: domain.Ingress{ContainerName: "api"},
+		},
+		Template: domain.ContainerAppTemplate{
+			Containers: []domain.Container{
+				{Name: "api", Image: "my-registry.cr.cloud.ru/api:1.0", ContainerPort: 8080},
+				{Name: "proxy", Image: "my-registry.cr.cloud.ru/proxy:1.0", ContainerPort: 9090},
+			},
+		},
+	}
+
+	spec, _ := cloneSpec(source, "project", domain.CloneOptions{TargetName: "api-copy"})
+	if spec.Configuration.Ingress.ContainerName != "api-copy" {
+		t.Fatalf("ingress container = %q, want api-copy", spec.Configuration.Ingress.ContainerName)
+	}
+	if err := domain.ValidateContainerAppSpec(&spec); err != nil {
+		t.Fatalf("ValidateContainerAppSpec() error = %v", err)
+	}
+
+	// An ingress container other than the main one keeps its name
+	source.Configuration.Ingress.ContainerName = "proxy"
+	spec, _ = cloneSpec(source, "project", domain.CloneOptions{TargetName: "api-copy"})
+	if spec.Configuration.Ingress.ContainerName != "proxy" {
+		t.Fatalf("ingress container = %q, want proxy", spec.Configuration.Ingress.ContainerName)
+	}
+}
@@ -0,0 +1,75 @@
+package application
+
+import (
+	"fmt"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+)
+
+// CreateOrUpdateContainerApp creates a ContainerApp, or handles an existing one according to ifExists:
+// error fails, skip leaves it as is and update converges it to the requested image, port and options.
+// Repeated calls with skip or update therefore succeed instead of failing on the conflict.
+func (c *ContainerAppsApplication) CreateOrUpdateContainerApp(projectID string, containerAppName string, containerAppPort int, containerAppImage string, options domain.ContainerAppOptions, ifExists string, credentials domain.Credentials) (*domain.CreateResult, error) {
+	if ifExists != domain.IfExistsError && ifExists != domain.IfExistsSkip && ifExists != domain.IfExistsUpdate {
+		return nil, fmt.Errorf("if_exists must be %s, %s or %s", domain.IfExistsError, domain.IfExistsSkip, domain.IfExistsUpdate)
+	}
+
+	if err := options.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid container app options: %w", err)
+	}
+
+	existing, err := c.GetContainerApp(projectID, containerAppName, credentials)
+	if err != nil && !domain.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to check whether container app %s exists: %w", containerAppName, err)
+	}
+
+	if existing == nil {
+		containerApp, err := c.CreateContainerApp(projectID, containerAppName, containerAppPort, containerAppImage, options, credentials)
+		if err == nil {
+			return &domain.CreateResult{Action: domain.CreateActionCreated, ContainerApp: containerApp}, nil
+		}
+		// Someone else may have created the container app after the check, then it is handled as an existing one
+		if !domain.IsConflict(err) {
+			return nil, err
+		}
+		existing, err = c.GetContainerApp(projectID, containerAppName, credentials)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get container app %s after a conflict: %w", containerAppName, err)
+		}
+	}
+
+	switch ifExists {
+	case domain.IfExistsSkip:
+		return &domain.CreateResult{Action: domain.CreateActionSkipped, ContainerApp: existing}, nil
+	case domain.IfExistsUpdate:
+		containerApp, err := c.convergeContainerApp(projectID, containerAppName, existing, containerAppPort, containerAppImage, options, credentials)
+		if err != nil {
+			return nil, err
+		}
+		return &domain.CreateResult{Action: domain.CreateActionUpdated, ContainerApp: containerApp}, nil
+	default:
+		return nil, fmt.Errorf("container app %s already exists in project %s, use if_exists=%s to keep it or if_exists=%s to update it", containerAppName, projectID, domain.IfExistsSkip, domain.IfExistsUpdate)
+	}
+}
+
+// convergeContainerApp updates the main container of an existing ContainerApp to the image and port and applies the options
+func (c *ContainerAppsApplication) convergeContainerApp(projectID string, containerAppName string, containerApp *domain.ContainerApp, containerAppPort int, containerAppImage string, options domain.ContainerAppOptions, credentials domain.Credentials) (*domain.ContainerApp, error) {
+	if len(containerApp.Template.Containers) == 0 {
+		return nil, fmt.Errorf("container app %s has no containers to update", containerAppName)
+	}
+
+	// The main container is the first one, sidecars follow it
+	main := &containerApp.Template.Containers[0]
+	main.Image = containerAppImage
+	main.ContainerPort = containerAppPort
+
+	if err := options.ApplyTo(containerApp); err != nil {
+		return nil, fmt.Errorf("invalid container app options: %w", err)
+	}
+
+	containerApp, err := c.updateContainerApp(projectID, containerAppName, containerApp, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update existing container app %s: %w", containerAppName, err)
+	}
+	return containerApp, nil
+}
@@ -0,0 +1,182 @@
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+)
+
+// ListCustomDomains gets the custom domains attached to a ContainerApp with their verification and certificate status
+func (c *ContainerAppsApplication) ListCustomDomains(projectID string, containerAppName string, credentials domain.Credentials) ([]domain.CustomDomain, error) {
+	containerApp, err := c.GetContainerApp(projectID, containerAppName, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get container app: %w", err)
+	}
+
+	// Get access token using KEY_ID and KEY_SECRET
+	token, err := c.getAccessToken(credentials.KeyID, credentials.KeySecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	url := fmt.Sprintf("https://containers.api.cloud.ru/v2/containers/%s/domains?projectId=%s", containerAppName, projectID)
+	statusCode, body, err := c.doAPIRequest("GET", url, token, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Log the response for debugging
+	log.Printf("ListCustomDomains response - Status: %d, Body length: %d, Body: %s", statusCode, len(body), string(body))
+
+	if statusCode != http.StatusOK {
+		return nil, &domain.APIError{StatusCode: statusCode, Body: string(body)}
+	}
+
+	var response struct {
+		Data []domain.CustomDomain `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse custom domains response: %w body length: %d body: %s", err, len(body), string(body))
+	}
+
+	domains := response.Data
+	for i := range domains {
+		completeCustomDomain(&domains[i], containerApp)
+	}
+	return domains, nil
+}
+
+// AttachCustomDomain attaches a custom domain to a publicly accessible ContainerApp.
+// The result contains the DNS records to create before the domain is verified and the certificate is issued.
+func (c *ContainerAppsApplication) AttachCustomDomain(projectID string, containerAppName string, domainName string, credentials domain.Credentials) (*domain.CustomDomain, error) {
+	domainName = strings.ToLower(strings.TrimSuffix(domainName, "."))
+	if err := domain.ValidateDomainName(domainName); err != nil {
+		return nil, err
+	}
+
+	containerApp, err := c.GetContainerApp(projectID, containerAppName, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get container app: %w", err)
+	}
+	// Custom domains route internet traffic, an internal-only app has nothing to route it to
+	if !containerApp.Configuration.Ingress.PubliclyAccessible {
+		return nil, fmt.Errorf("container app %s is not publicly accessible, make it public with cloudru_update_containerapp before attaching a custom domain", containerAppName)
+	}
+
+	// Get access token using KEY_ID and KEY_SECRET
+	token, err := c.getAccessToken(credentials.KeyID, credentials.KeySecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	payload := map[string]interface{}{
+		"name": domainName,
+	}
+	url := fmt.Sprintf("https://containers.api.cloud.ru/v2/containers/%s/domains?projectId=%s", containerAppName, projectID)
+	statusCode, body, err := c.doAPIRequest("POST", url, token, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	// Log the response for debugging
+	log.Printf("AttachCustomDomain response - Status: %d, Body length: %d, Body: %s", statusCode, len(body), string(body))
+
+	if statusCode != http.StatusOK && statusCode != http.StatusCreated && statusCode != http.StatusAccepted {
+		apiErr := &domain.APIError{StatusCode: statusCode, Body: string(body)}
+		if domain.IsConflict(apiErr) {
+			return nil, fmt.Errorf("domain %s is already attached to a container app: %w", domainName, apiErr)
+		}
+		return nil, apiErr
+	}
+
+	customDomain := domain.CustomDomain{}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &customDomain); err != nil {
+			return nil, fmt.Errorf("failed to parse custom domain response: %w body length: %d body: %s", err, len(body), string(body))
+		}
+	}
+	// The API may answer with the operation only, then the domain is pending until the DNS records exist
+	if customDomain.Name == "" {
+		customDomain = domain.CustomDomain{
+			Name:               domainName,
+			VerificationStatus: domain.DomainVerificationPending,
+			CertificateStatus:  domain.CertificateStatusPending,
+		}
+	}
+	customDomain.OperationID = operationIDFromResponse(body)
+	completeCustomDomain(&customDomain, containerApp)
+
+	return &customDomain, nil
+}
+
+// DetachCustomDomain detaches a custom domain from a ContainerApp, the generated PublicUri keeps working.
+// Protected Container Apps keep their domains, and every detach is recorded in the audit log.
+func (c *ContainerAppsApplication) DetachCustomDomain(projectID string, containerAppName string, domainName string, credentials domain.Credentials) (string, error) {
+	domainName = strings.ToLower(strings.TrimSuffix(domainName, "."))
+	if err := domain.ValidateDomainName(domainName); err != nil {
+		return "", err
+	}
+	if err := c.guardrails.CheckDestructiveAction(domain.DestructiveActionDetachDomain, containerAppName); err != nil {
+		return "", err
+	}
+
+	operationID, detachErr := c.detachCustomDomain(projectID, containerAppName, domainName, credentials)
+
+	record := domain.AuditRecord{
+		Time:             time.Now().UTC(),
+		Action:           domain.DestructiveActionDetachDomain,
+		ProjectID:        projectID,
+		ContainerAppName: containerAppName,
+		CustomDomain:     domainName,
+		OperationID:      operationID,
+	}
+	if detachErr != nil {
+		record.Error = detachErr.Error()
+	}
+	if err := c.guardrails.RecordDestructiveAction(record); err != nil {
+		log.Printf("DetachCustomDomain - failed to record detach of %s from %s: %v", domainName, containerAppName, err)
+	}
+
+	return operationID, detachErr
+}
+
+// detachCustomDomain removes a custom domain from a ContainerApp in Cloud.ru
+func (c *ContainerAppsApplication) detachCustomDomain(projectID string, containerAppName string, domainName string, credentials domain.Credentials) (string, error) {
+	// Get access token using KEY_ID and KEY_SECRET
+	token, err := c.getAccessToken(credentials.KeyID, credentials.KeySecret)
+	if err != nil {
+		return "", fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	url := fmt.Sprintf("https://containers.api.cloud.ru/v2/containers/%s/domains/%s?projectId=%s", containerAppName, domainName, projectID)
+	statusCode, body, err := c.doAPIRequest("DELETE", url, token, nil)
+	if err != nil {
+		return "", err
+	}
+
+	// Log the response for debugging
+	log.Printf("DetachCustomDomain response - Status: %d, Body length: %d, Body: %s", statusCode, len(body), string(body))
+
+	if statusCode != http.StatusNoContent && statusCode != http.StatusOK && statusCode != http.StatusAccepted {
+		apiErr := &domain.APIError{StatusCode: statusCode, Body: string(body)}
+		if domain.IsNotFound(apiErr) {
+			return "", fmt.Errorf("domain %s is not attached to container app %s: %w", domainName, containerAppName, apiErr)
+		}
+		return "", apiErr
+	}
+
+	return operationIDFromResponse(body), nil
+}
+
+// completeCustomDomain fills in the Container App name if the API didn't return it.
+// DNS records are only reported as the API returns them, they are not guessed.
+func completeCustomDomain(customDomain *domain.CustomDomain, containerApp *domain.ContainerApp) {
+	if customDomain.ContainerAppName == "" {
+		customDomain.ContainerAppName = containerApp.Name
+	}
+}
@@ -0,0 +1,206 @@
+package application
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ExportContainerApp converts a live ContainerApp into a YAML or JSON manifest without server-assigned fields,
+// empty values and secret values. If outputPath is set, the manifest is also written to that file.
+// The file must be inside the current directory, an existing file is only replaced if overwrite is set.
+func (c *ContainerAppsApplication) ExportContainerApp(projectID string, containerAppName string, format string, outputPath string, overwrite bool, credentials domain.Credentials) (string, error) {
+	if format != domain.ManifestFormatYAML && format != domain.ManifestFormatJSON {
+		return "", fmt.Errorf("manifest format must be %s or %s", domain.ManifestFormatYAML, domain.ManifestFormatJSON)
+	}
+
+	containerApp, err := c.GetContainerApp(projectID, containerAppName, credentials)
+	if err != nil {
+		return "", fmt.Errorf("failed to get container app: %w", err)
+	}
+
+	manifest, err := containerAppManifest(*containerApp)
+	if err != nil {
+		return "", err
+	}
+
+	var content bytes.Buffer
+	if format == domain.ManifestFormatYAML {
+		encoder := yaml.NewEncoder(&content)
+		encoder.SetIndent(2)
+		err = encoder.Encode(manifest)
+	} else {
+		encoder := json.NewEncoder(&content)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(manifest)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to format manifest: %w", err)
+	}
+
+	if outputPath != "" {
+		if err := writeManifestFile(outputPath, content.Bytes(), overwrite); err != nil {
+			return "", err
+		}
+	}
+
+	return content.String(), nil
+}
+
+// writeManifestFile writes the manifest to a path relative to the current directory.
+// Paths leaving the current directory, directly or through symlinks, are refused so that a tool call can't overwrite
+// arbitrary files of the user such as shell profiles or SSH keys.
+func writeManifestFile(outputPath string, content []byte, overwrite bool) error {
+	if filepath.IsAbs(outputPath) {
+		return fmt.Errorf("output path %s must be relative to the current directory", outputPath)
+	}
+	cleanPath := filepath.Clean(outputPath)
+	if cleanPath == "." || cleanPath == ".." || strings.HasPrefix(cleanPath, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("output path %s must be a file inside the current directory", outputPath)
+	}
+
+	currentDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	currentDir, err = filepath.EvalSymlinks(currentDir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve current directory: %w", err)
+	}
+
+	// Check the deepest existing directory of the path before creating anything, the missing ones are created as plain directories
+	existingDir := filepath.Join(currentDir, filepath.Dir(cleanPath))
+	for {
+		if _, err := os.Lstat(existingDir); err == nil {
+			break
+		}
+		existingDir = filepath.Dir(existingDir)
+	}
+	resolvedDir, err := filepath.EvalSymlinks(existingDir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve manifest directory: %w", err)
+	}
+	if relativeDir, err := filepath.Rel(currentDir, resolvedDir); err != nil || relativeDir == ".." || strings.HasPrefix(relativeDir, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("output path %s must be a file inside the current directory", outputPath)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cleanPath), 0o755); err != nil {
+		return fmt.Errorf("failed to create manifest directory: %w", err)
+	}
+
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if info, err := os.Lstat(cleanPath); err == nil {
+		if !overwrite {
+			return fmt.Errorf("file %s already exists, use overwrite to replace it", outputPath)
+		}
+		if !info.Mode().IsRegular() {
+			return fmt.Errorf("output path %s is not a regular file", outputPath)
+		}
+		flags = os.O_WRONLY | os.O_TRUNC
+	}
+
+	file, err := os.OpenFile(cleanPath, flags, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to write manifest to %s: %w", outputPath, err)
+	}
+	if _, err := file.Write(content); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write manifest to %s: %w", outputPath, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write manifest to %s: %w", outputPath, err)
+	}
+	return nil
+}
+
+// containerAppManifest builds a portable manifest of the ContainerApp.
+// Secret environment variables keep their names, but their values are replaced by ${NAME} placeholders.
+func containerAppManifest(containerApp domain.ContainerApp) (map[string]interface{}, error) {
+	spec, _ := cloneSpec(containerApp, "", domain.CloneOptions{
+		TargetName:  containerApp.Name,
+		CopySecrets: true,
+	})
+	for _, containers := range [][]domain.Container{spec.Template.Containers, spec.Template.InitContainers} {
+		for _, container := range containers {
+			for i := range container.Env {
+				if strings.EqualFold(container.Env[i].Type, domain.EnvVarTypeSecret) {
+					container.Env[i].Value = "${" + container.Env[i].Name + "}"
+				}
+			}
+		}
+	}
+
+	// Go through JSON to get the API field names, then drop everything that is empty
+	specJSON, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal container app: %w", err)
+	}
+	var manifest map[string]interface{}
+	if err := json.Unmarshal(specJSON, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to convert container app: %w", err)
+	}
+	delete(manifest, "projectId")
+	pruneEmptyValues(manifest)
+
+	// A false publiclyAccessible is not a default, it marks an internal-only app, so it is always kept
+	configuration, _ := manifest["configuration"].(map[string]interface{})
+	if configuration == nil {
+		configuration = map[string]interface{}{}
+		manifest["configuration"] = configuration
+	}
+	ingress, _ := configuration["ingress"].(map[string]interface{})
+	if ingress == nil {
+		ingress = map[string]interface{}{}
+		configuration["ingress"] = ingress
+	}
+	ingress["publiclyAccessible"] = containerApp.Configuration.Ingress.PubliclyAccessible
+
+	return manifest, nil
+}
+
+// pruneEmptyValues recursively removes nulls, zero numbers, false booleans, empty strings and empty collections from a map.
+// Elements of lists are kept as is so that positional values like command arguments are not lost.
+func pruneEmptyValues(values map[string]interface{}) {
+	for key, value := range values {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			pruneEmptyValues(v)
+		case []interface{}:
+			for _, item := range v {
+				if itemMap, ok := item.(map[string]interface{}); ok {
+					pruneEmptyValues(itemMap)
+				}
+			}
+		}
+		if isEmptyValue(values[key]) {
+			delete(values, key)
+		}
+	}
+}
+
+// isEmptyValue reports whether a decoded JSON value is empty
+func isEmptyValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case float64:
+		return v == 0
+	case string:
+		return v == ""
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
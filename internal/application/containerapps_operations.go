@@ -0,0 +1,77 @@
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+)
+
+// GetOperation gets the state of an asynchronous platform operation from Cloud.ru API
+func (c *ContainerAppsApplication) GetOperation(operationID string, credentials domain.Credentials) (*domain.Operation, error) {
+	if operationID == "" {
+		return nil, fmt.Errorf("operation ID must not be empty")
+	}
+	// The ID is a single path segment, so a slash would address another endpoint of the API
+	if strings.Contains(operationID, "/") {
+		return nil, fmt.Errorf("operation ID %q must not contain /", operationID)
+	}
+
+	// Get access token using KEY_ID and KEY_SECRET
+	token, err := c.getAccessToken(credentials.KeyID, credentials.KeySecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	requestURL := fmt.Sprintf("https://operations.api.cloud.ru/v1/operations/%s", url.PathEscape(operationID))
+	statusCode, body, err := c.doAPIRequest("GET", requestURL, token, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Log the response for debugging
+	log.Printf("GetOperation response - Status: %d, Body length: %d, Body: %s", statusCode, len(body), string(body))
+
+	if statusCode != http.StatusOK {
+		return nil, &domain.APIError{StatusCode: statusCode, Body: string(body)}
+	}
+
+	var operation domain.Operation
+	if err := json.Unmarshal(body, &operation); err != nil {
+		return nil, fmt.Errorf("failed to parse operation response: %w body length: %d body: %s", err, len(body), string(body))
+	}
+
+	return &operation, nil
+}
+
+// operationIDFromResponse extracts the ID of the asynchronous operation started by a request.
+// The API either answers with the operation itself or references it by an operationId field.
+func operationIDFromResponse(body []byte) string {
+	var response struct {
+		OperationID string `json:"operationId"`
+		Operation   *struct {
+			ID string `json:"id"`
+		} `json:"operation"`
+		ID   string `json:"id"`
+		Done *bool  `json:"done"`
+	}
+	if len(body) == 0 || json.Unmarshal(body, &response) != nil {
+		return ""
+	}
+
+	switch {
+	case response.OperationID != "":
+		return response.OperationID
+	case response.Operation != nil:
+		return response.Operation.ID
+	case response.Done != nil:
+		// Only an operation has the done flag, so its id is the operation ID
+		return response.ID
+	default:
+		return ""
+	}
+}
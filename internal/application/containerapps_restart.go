@@ -0,0 +1,89 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+)
+
+const (
+	// statusPollInterval is how often the Container App status is checked while waiting for a transition
+	statusPollInterval = 5 * time.Second
+	// statusWaitTimeout is how long to wait for a single status transition
+	statusWaitTimeout = 5 * time.Minute
+)
+
+// RestartContainerApp stops a ContainerApp, waits until it is stopped, then starts it and waits until it is running.
+// Waiting stops when the context is cancelled. A restart is refused for protected ContainerApps like a stop,
+// because the app stays stopped if it fails to start again, and every restart is recorded in the audit log with its outcome.
+func (c *ContainerAppsApplication) RestartContainerApp(ctx context.Context, projectID string, containerAppName string, credentials domain.Credentials) (*domain.RestartResult, error) {
+	var result *domain.RestartResult
+	_, err := c.guardDestructiveAction(domain.DestructiveActionRestart, projectID, containerAppName, credentials, func(projectID string, containerAppName string, credentials domain.Credentials) (string, error) {
+		var operationID string
+		var err error
+		result, operationID, err = c.restartContainerApp(ctx, projectID, containerAppName, credentials)
+		return operationID, err
+	})
+	return result, err
+}
+
+// restartContainerApp stops and starts a ContainerApp, it returns the ID of the stop operation if the API reports one
+func (c *ContainerAppsApplication) restartContainerApp(ctx context.Context, projectID string, containerAppName string, credentials domain.Credentials) (*domain.RestartResult, string, error) {
+	result := &domain.RestartResult{}
+
+	stopStartedAt := time.Now()
+	operationID, err := c.stopContainerApp(projectID, containerAppName, credentials)
+	if err != nil {
+		return nil, operationID, fmt.Errorf("failed to stop container app %s, it was not restarted: %w", containerAppName, err)
+	}
+	if err := c.waitForContainerAppStatus(ctx, projectID, containerAppName, domain.ContainerAppStatusStopped, credentials); err != nil {
+		return nil, operationID, fmt.Errorf("container app %s did not stop, it was not started again: %w", containerAppName, err)
+	}
+	result.StopDuration = time.Since(stopStartedAt)
+
+	// From here on the container app is stopped, so errors must tell that it has to be started again
+	startStartedAt := time.Now()
+	if _, err := c.StartContainerApp(projectID, containerAppName, credentials); err != nil {
+		return result, operationID, fmt.Errorf("container app %s was stopped in %s but failed to start, it is stopped now and must be started with cloudru_start_containerapp: %w", containerAppName, result.StopDuration.Round(time.Second), err)
+	}
+	if err := c.waitForContainerAppStatus(ctx, projectID, containerAppName, domain.ContainerAppStatusRunning, credentials); err != nil {
+		return result, operationID, fmt.Errorf("container app %s was stopped in %s and start was requested, but it did not become running: %w", containerAppName, result.StopDuration.Round(time.Second), err)
+	}
+	result.StartDuration = time.Since(startStartedAt)
+
+	return result, operationID, nil
+}
+
+// waitForContainerAppStatus polls the ContainerApp until it reaches the wanted status, fails, the wait times out
+// or the context is cancelled
+func (c *ContainerAppsApplication) waitForContainerAppStatus(ctx context.Context, projectID string, containerAppName string, wantedStatus string, credentials domain.Credentials) error {
+	deadline := time.Now().Add(statusWaitTimeout)
+	for {
+		containerApp, err := c.GetContainerApp(projectID, containerAppName, credentials)
+		if err != nil {
+			return fmt.Errorf("failed to get container app status: %w", err)
+		}
+
+		log.Printf("waitForContainerAppStatus - Container App: %s, Status: %s, Wanted: %s", containerAppName, containerApp.Status, wantedStatus)
+
+		if strings.EqualFold(containerApp.Status, wantedStatus) {
+			return nil
+		}
+		if strings.EqualFold(containerApp.Status, domain.ContainerAppStatusFailed) {
+			return fmt.Errorf("container app is in %s status", containerApp.Status)
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for status %s, current status is %s", statusWaitTimeout, wantedStatus, containerApp.Status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("stopped waiting for status %s, current status is %s: %w", wantedStatus, containerApp.Status, ctx.Err())
+		case <-time.After(statusPollInterval):
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package application
 
 import (
+	"strings"
+
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/config"
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
 )
@@ -22,14 +24,32 @@ func (d *DescriptionApplication) GetDescription() string {
 1. cloudru_containerapps_description() - Returns usage instructions for this MCP
 2. cloudru_get_list_docker_registries(project_id, key_id, key_secret) - Get list of Docker Registries
 3. cloudru_create_docker_registry(project_id, registry_name, is_public, key_id, key_secret) - Create a new Docker Registry
-4. cloudru_docker_login(registry_name, key_id, key_secret) - Login to Docker registry
-5. cloudru_docker_push(registry_name, repository_name, image_version, key_id, key_secret) - Build and push Docker image
-6. cloudru_get_list_containerapps(project_id, key_id, key_secret) - Get list of Container Apps
-7. cloudru_get_containerapp(project_id, containerapp_name, key_id, key_secret) - Get a specific Container App by name
-8. cloudru_create_containerapp(project_id, containerapp_name, containerapp_port, containerapp_image, key_id, key_secret) - Create a new Container App
-9. cloudru_delete_containerapp(project_id, containerapp_name, key_id, key_secret) - Delete a Container App (WARNING: This action cannot be undone!)
-10. cloudru_start_containerapp(project_id, containerapp_name, key_id, key_secret) - Start a Container App
-11. cloudru_stop_containerapp(project_id, containerapp_name, key_id, key_secret) - Stop a Container App
+4. cloudru_apply_docker_registry_retention(project_id, registry_name, keep_last_tags, delete_untagged, delete_older_than_days, protected_tag_patterns, preview, force, key_id, key_secret) - Delete the images selected by retention rules once (WARNING: cannot be undone! preview=true by default lists the images and which of them Container Apps still use; used images are only deleted with force=true; refused for protected registries)
+5. cloudru_delete_docker_registry(project_id, registry_name, confirm_registry_name, force, key_id, key_secret) - Delete a Docker Registry (WARNING: cannot be undone! confirm_registry_name must repeat the exact name; refused for protected registries, and while running Container Apps use its images unless force=true)
+6. cloudru_list_repositories(project_id, registry_name, key_id, key_secret) - List repositories of a Docker Registry with tag count and size
+7. cloudru_list_image_tags(project_id, registry_name, repository_name, key_id, key_secret) - List image tags of a repository (newest first) with digest, size, push time, platforms and the full image reference for cloudru_create_containerapp
+8. cloudru_delete_image(project_id, registry_name, repository_name, tag_or_digest, confirm_tag_or_digest, key_id, key_secret) - Delete an image tag, or a digest with all its tags (WARNING: cannot be undone! refused in protected registries and while a Container App of the project still uses the image)
+9. cloudru_docker_login(registry_name, key_id, key_secret) - Login to Docker registry
+10. cloudru_docker_push(registry_name, repository_name, image_version, key_id, key_secret) - Build and push Docker image
+11. cloudru_get_list_containerapps(project_id, name_pattern, name_regex, status, image_contains, visibility, label_selector, summary, key_id, key_secret) - Get list of Container Apps filtered by name glob/regex, status, image, visibility and labels (summary=true for a compact listing)
+12. cloudru_get_containerapp(project_id, containerapp_name, key_id, key_secret) - Get a specific Container App by name
+13. cloudru_create_containerapp(project_id, containerapp_name, containerapp_port, containerapp_image, publicly_accessible, additional_port_mappings, volumes, volume_mounts, command, args, init_containers, autodeployments_enabled, autodeployments_pattern, timeout, idle_timeout, protocol, sidecars, ingress_container, containerapp_description, labels, if_exists, key_id, key_secret) - Create a new Container App (publicly_accessible=false creates an internal-only app, if_exists=error|skip|update handles an existing app, labels like team=billing,env=dev)
+14. cloudru_update_containerapp(project_id, containerapp_name, publicly_accessible, additional_port_mappings, volumes, volume_mounts, command, args, init_containers, autodeployments_enabled, autodeployments_pattern, timeout, idle_timeout, protocol, sidecars, ingress_container, containerapp_description, labels, key_id, key_secret) - Update settings of an existing Container App (labels are merged, an empty value removes a label)
+15. cloudru_set_containerapp_autodeployments(project_id, containerapp_name, autodeployments_enabled, autodeployments_pattern, key_id, key_secret) - Enable or disable auto-deployment of a Container App when an image tag matching the pattern is pushed
+16. cloudru_clone_containerapp(project_id, containerapp_name, target_containerapp_name, target_project_id, target_containerapp_image, env, copy_secrets, key_id, key_secret) - Copy a Container App under a new name or into another project (secrets are only copied with copy_secrets=true)
+17. cloudru_export_containerapp(project_id, containerapp_name, manifest_format, output_path, overwrite, key_id, key_secret) - Export a Container App to a clean YAML or JSON manifest (secrets replaced by placeholders), optionally written to a file inside the current directory (an existing file is only replaced with overwrite=true)
+18. cloudru_delete_containerapp(project_id, containerapp_name, confirm_containerapp_name, key_id, key_secret) - Delete a Container App (WARNING: This action cannot be undone! confirm_containerapp_name must repeat the exact name)
+19. cloudru_start_containerapp(project_id, containerapp_name, key_id, key_secret) - Start a Container App
+20. cloudru_stop_containerapp(project_id, containerapp_name, key_id, key_secret) - Stop a Container App
+21. cloudru_restart_containerapp(project_id, containerapp_name, key_id, key_secret) - Restart a Container App (stop, wait, start, wait) and report how long each phase took
+22. cloudru_bulk_containerapps(project_id, bulk_action, name_pattern, name_regex, status, image_contains, visibility, label_selector, dry_run, concurrency, confirm_containerapp_names, key_id, key_secret) - Start, stop or delete all Container Apps matching a selector (dry_run=true by default shows a preview first)
+23. cloudru_get_operation(operation_id, key_id, key_secret) - Check progress, errors and completion of an asynchronous operation (create, update, delete, start and stop return its ID)
+24. cloudru_list_schedules() - List schedules that start and stop Container Apps, with last and next runs
+25. cloudru_add_schedule(schedule_name, project_id, name_pattern, name_regex, image_contains, visibility, label_selector, start_cron, stop_cron, timezone) - Start and stop matching Container Apps on cron rules (e.g. stop_cron="0 20 * * mon-fri")
+26. cloudru_remove_schedule(schedule_name) - Remove a schedule
+27. cloudru_list_containerapp_domains(project_id, containerapp_name, key_id, key_secret) - List custom domains of a Container App with verification status, certificate status and the DNS records they need
+28. cloudru_attach_containerapp_domain(project_id, containerapp_name, domain_name, key_id, key_secret) - Attach a custom domain to a public Container App, returns the DNS records (type, host, value, purpose) to create
+29. cloudru_detach_containerapp_domain(project_id, containerapp_name, domain_name, confirm_domain_name, key_id, key_secret) - Detach a custom domain (confirm_domain_name must repeat the exact domain)
 
 Environment variables can be used as fallbacks for parameters:
 
@@ -50,6 +70,10 @@ You will need a Key ID and Key Secret to use this service.
 - CLOUDRU_PROJECT_ID: Project ID for Container Apps (can be obtained from console.cloud.ru)
 - CLOUDRU_CONTAINERAPP_NAME: Container App name (optional)
 - CLOUDRU_DOCKERFILE: Path to Dockerfile (defaults to "Dockerfile" if not set)
+- CLOUDRU_PROTECTED_CONTAINERAPPS: Comma-separated names or glob patterns of Container Apps that can't be deleted, stopped or lose their custom domains
+- CLOUDRU_AUDIT_LOG: Path to the audit log of deletes and stops (defaults to "~/.cloudru-containerapps-mcp/audit.jsonl")
+- CLOUDRU_SCHEDULES: Path to the JSON file with start/stop schedules (defaults to "~/.cloudru-containerapps-mcp/schedules.json")
+- CLOUDRU_SCHEDULER_STATE: Path to the file with the last runs of the schedules (defaults to "~/.cloudru-containerapps-mcp/scheduler_state.json")
 
 Current configuration values:
 - CLOUDRU_REGISTRY_NAME: (` + cfg.RegistryName + `) (Registry for storing Docker images)
@@ -58,6 +82,8 @@ Current configuration values:
 - CLOUDRU_DOCKERFILE: (` + cfg.Dockerfile + `) (Path to the Dockerfile to build the image, by default Dockerfile)
 - CLOUDRU_KEY_ID: (` + maskSensitiveInfo(cfg.KeyID) + `) (Authentication key identifier)
 - CLOUDRU_KEY_SECRET: (` + maskSensitiveInfo(cfg.KeySecret) + `) (Authentication key secret)
+- CLOUDRU_PROTECTED_CONTAINERAPPS: (` + strings.Join(cfg.ProtectedContainerApps, ",") + `) (Container Apps that can't be deleted, stopped or lose their custom domains)
+- CLOUDRU_PROTECTED_REGISTRIES: (` + strings.Join(cfg.ProtectedRegistries, ",") + `) (Docker Registries that can't be deleted and whose images can't be deleted)
 - Current directory: ` + cfg.CurrentDir + ` (Name of the current working directory)
 
 For more details see: https://cloud.ru/docs/container-apps-evolution/ug/topics/tutorials__before-work`
@@ -0,0 +1,122 @@
+package application
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+)
+
+// DeleteDockerRegistry deletes a Docker Registry with all its images from Cloud.ru.
+// Protected registries are never deleted. It refuses to delete a registry whose images are used by running Container Apps
+// unless force is set, because those apps could not be restarted or scaled out anymore. Every deletion is recorded in the audit log.
+func (c *ContainerAppsApplication) DeleteDockerRegistry(projectID string, registryName string, force bool, credentials domain.Credentials) (*domain.DeleteRegistryResult, error) {
+	if err := c.guardrails.CheckRegistryDestructiveAction(domain.DestructiveActionDeleteRegistry, registryName); err != nil {
+		return nil, err
+	}
+
+	registry, err := c.findDockerRegistry(projectID, registryName, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	referencingContainerApps, err := c.containerAppsUsingImages(projectID, true, credentials, func(reference domain.ImageReference) bool {
+		return reference.RegistryName() == registryName
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check container apps using registry %s: %w", registryName, err)
+	}
+	if len(referencingContainerApps) > 0 && !force {
+		return nil, fmt.Errorf("registry %s holds images of running container apps %s, point them to other images or stop them first, or use force to delete the registry anyway", registryName, strings.Join(referencingContainerApps, ", "))
+	}
+
+	operationID, deleteErr := c.deleteDockerRegistry(projectID, registry.ID, credentials)
+
+	record := domain.AuditRecord{
+		Time:         time.Now().UTC(),
+		Action:       domain.DestructiveActionDeleteRegistry,
+		ProjectID:    projectID,
+		RegistryName: registryName,
+		OperationID:  operationID,
+		Force:        force && len(referencingContainerApps) > 0,
+	}
+	if deleteErr != nil {
+		record.Error = deleteErr.Error()
+	}
+	if err := c.guardrails.RecordDestructiveAction(record); err != nil {
+		log.Printf("DeleteDockerRegistry - failed to record deletion of %s: %v", registryName, err)
+	}
+	if deleteErr != nil {
+		return nil, deleteErr
+	}
+
+	return &domain.DeleteRegistryResult{
+		RegistryName:             registryName,
+		ReferencingContainerApps: referencingContainerApps,
+		OperationID:              operationID,
+	}, nil
+}
+
+// deleteDockerRegistry deletes a Docker Registry by its ID in Cloud.ru
+func (c *ContainerAppsApplication) deleteDockerRegistry(projectID string, registryID string, credentials domain.Credentials) (string, error) {
+	// Get access token using KEY_ID and KEY_SECRET
+	token, err := c.getAccessToken(credentials.KeyID, credentials.KeySecret)
+	if err != nil {
+		return "", fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	url := fmt.Sprintf("https://ar.api.cloud.ru/v1/projects/%s/registries/%s", projectID, registryID)
+	statusCode, body, err := c.doAPIRequest("DELETE", url, token, nil)
+	if err != nil {
+		return "", err
+	}
+
+	// Log the response for debugging
+	log.Printf("DeleteDockerRegistry response - Status: %d, Body length: %d, Body: %s", statusCode, len(body), string(body))
+
+	if statusCode != http.StatusNoContent && statusCode != http.StatusOK && statusCode != http.StatusAccepted {
+		return "", &domain.APIError{StatusCode: statusCode, Body: string(body)}
+	}
+
+	return operationIDFromResponse(body), nil
+}
+
+// findDockerRegistry returns the Docker Registry with the given name
+func (c *ContainerAppsApplication) findDockerRegistry(projectID string, registryName string, credentials domain.Credentials) (*domain.DockerRegistry, error) {
+	registries, err := c.GetListDockerRegistries(projectID, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get docker registries: %w", err)
+	}
+	for _, registry := range registries {
+		if registry.Name == registryName {
+			return &registry, nil
+		}
+	}
+	return nil, fmt.Errorf("docker registry %s not found in project %s", registryName, projectID)
+}
+
+// containerAppsUsingImages returns the names of the Container Apps with at least one image matching the predicate,
+// optionally only the running ones
+func (c *ContainerAppsApplication) containerAppsUsingImages(projectID string, onlyRunning bool, credentials domain.Credentials, matches func(reference domain.ImageReference) bool) ([]string, error) {
+	containerApps, err := c.GetListContainerApps(projectID, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, containerApp := range containerApps {
+		if onlyRunning && !strings.EqualFold(containerApp.Status, domain.ContainerAppStatusRunning) {
+			continue
+		}
+		for _, image := range containerApp.Images() {
+			if matches(domain.ParseImageReference(image)) {
+				names = append(names, containerApp.Name)
+				break
+			}
+		}
+	}
+	return names, nil
+}
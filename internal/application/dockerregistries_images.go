@@ -0,0 +1,249 @@
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+)
+
+// ListRepositories gets the repositories of a Docker Registry from Cloud.ru API
+func (c *ContainerAppsApplication) ListRepositories(projectID string, registryName string, credentials domain.Credentials) ([]domain.Repository, error) {
+	registry, err := c.findDockerRegistry(projectID, registryName, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.listRepositories(projectID, registry, credentials)
+}
+
+// listRepositories gets the repositories of an already resolved Docker Registry
+func (c *ContainerAppsApplication) listRepositories(projectID string, registry *domain.DockerRegistry, credentials domain.Credentials) ([]domain.Repository, error) {
+	// Get access token using KEY_ID and KEY_SECRET
+	token, err := c.getAccessToken(credentials.KeyID, credentials.KeySecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	requestURL := fmt.Sprintf("https://ar.api.cloud.ru/v1/projects/%s/registries/%s/repositories", projectID, registry.ID)
+	statusCode, body, err := c.doAPIRequest("GET", requestURL, token, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Log the response for debugging
+	log.Printf("ListRepositories response - Status: %d, Body length: %d, Body: %s", statusCode, len(body), string(body))
+
+	if statusCode != http.StatusOK {
+		return nil, &domain.APIError{StatusCode: statusCode, Body: string(body)}
+	}
+
+	var response struct {
+		Repositories []domain.Repository `json:"repositories"`
+	}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &response); err != nil {
+			return nil, fmt.Errorf("failed to parse repositories response: %w body length: %d body: %s", err, len(body), string(body))
+		}
+	}
+
+	repositories := response.Repositories
+	if repositories == nil {
+		repositories = []domain.Repository{}
+	}
+	sort.Slice(repositories, func(i, j int) bool {
+		return repositories[i].Name < repositories[j].Name
+	})
+	return repositories, nil
+}
+
+// ListImageTags gets the tags of a repository in a Docker Registry from Cloud.ru API, the most recently pushed first
+func (c *ContainerAppsApplication) ListImageTags(projectID string, registryName string, repositoryName string, credentials domain.Credentials) ([]domain.ImageTag, error) {
+	if err := domain.ValidateRepositoryName(repositoryName); err != nil {
+		return nil, err
+	}
+
+	registry, err := c.findDockerRegistry(projectID, registryName, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.listImageTags(projectID, registry, repositoryName, credentials)
+}
+
+// listImageTags gets the tags of a repository in an already resolved Docker Registry, the most recently pushed first
+func (c *ContainerAppsApplication) listImageTags(projectID string, registry *domain.DockerRegistry, repositoryName string, credentials domain.Credentials) ([]domain.ImageTag, error) {
+	registryName := registry.Name
+
+	// Get access token using KEY_ID and KEY_SECRET
+	token, err := c.getAccessToken(credentials.KeyID, credentials.KeySecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	// Repository names may contain slashes, so the name is escaped as a single path segment
+	requestURL := fmt.Sprintf("https://ar.api.cloud.ru/v1/projects/%s/registries/%s/repositories/%s/tags", projectID, registry.ID, url.PathEscape(repositoryName))
+	statusCode, body, err := c.doAPIRequest("GET", requestURL, token, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Log the response for debugging
+	log.Printf("ListImageTags response - Status: %d, Body length: %d, Body: %s", statusCode, len(body), string(body))
+
+	if statusCode != http.StatusOK {
+		apiErr := &domain.APIError{StatusCode: statusCode, Body: string(body)}
+		if domain.IsNotFound(apiErr) {
+			return nil, fmt.Errorf("repository %s not found in registry %s: %w", repositoryName, registryName, apiErr)
+		}
+		return nil, apiErr
+	}
+
+	var response struct {
+		Tags []domain.ImageTag `json:"tags"`
+	}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &response); err != nil {
+			return nil, fmt.Errorf("failed to parse tags response: %w body length: %d body: %s", err, len(body), string(body))
+		}
+	}
+
+	tags := response.Tags
+	if tags == nil {
+		tags = []domain.ImageTag{}
+	}
+	for i := range tags {
+		tags[i].Image = fmt.Sprintf("%s/%s:%s", domain.RegistryHost(registryName), repositoryName, tags[i].Tag)
+	}
+	domain.SortImageTagsByPushTime(tags)
+	return tags, nil
+}
+
+// DeleteImage deletes an image tag, or an image digest together with all its tags, from a repository.
+// Images of protected registries are never deleted. It refuses to delete an image that is still referenced
+// by a Container App of the project, by tag or by digest.
+func (c *ContainerAppsApplication) DeleteImage(projectID string, registryName string, repositoryName string, tagOrDigest string, credentials domain.Credentials) (*domain.DeleteImageResult, error) {
+	isDigest := domain.IsImageDigest(tagOrDigest)
+	if !isDigest {
+		if err := domain.ValidateImageTag(tagOrDigest); err != nil {
+			return nil, err
+		}
+	}
+	if err := domain.ValidateRepositoryName(repositoryName); err != nil {
+		return nil, err
+	}
+	if err := c.guardrails.CheckRegistryDestructiveAction(domain.DestructiveActionDeleteImage, registryName); err != nil {
+		return nil, err
+	}
+
+	registry, err := c.findDockerRegistry(projectID, registryName, credentials)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := c.listImageTags(projectID, registry, repositoryName, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the digest and every tag that disappears with the deletion
+	result := &domain.DeleteImageResult{
+		DeletedTags: []string{},
+	}
+	if isDigest {
+		result.Digest = tagOrDigest
+		result.Image = fmt.Sprintf("%s/%s@%s", domain.RegistryHost(registryName), repositoryName, tagOrDigest)
+		for _, tag := range tags {
+			if tag.Digest == tagOrDigest {
+				result.DeletedTags = append(result.DeletedTags, tag.Tag)
+			}
+		}
+	} else {
+		result.Image = fmt.Sprintf("%s/%s:%s", domain.RegistryHost(registryName), repositoryName, tagOrDigest)
+		for _, tag := range tags {
+			if tag.Tag == tagOrDigest {
+				result.Digest = tag.Digest
+				result.DeletedTags = append(result.DeletedTags, tag.Tag)
+			}
+		}
+		if len(result.DeletedTags) == 0 {
+			return nil, fmt.Errorf("tag %s not found in repository %s of registry %s", tagOrDigest, repositoryName, registryName)
+		}
+	}
+
+	deletedTags := map[string]bool{}
+	for _, tag := range result.DeletedTags {
+		deletedTags[tag] = true
+	}
+	referencingContainerApps, err := c.containerAppsUsingImages(projectID, false, credentials, func(reference domain.ImageReference) bool {
+		if reference.RegistryName() != registryName || reference.Repository != repositoryName {
+			return false
+		}
+		if reference.Digest != "" {
+			return isDigest && reference.Digest == result.Digest
+		}
+		return deletedTags[reference.EffectiveTag()]
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check container apps using %s: %w", result.Image, err)
+	}
+	if len(referencingContainerApps) > 0 {
+		return nil, fmt.Errorf("image %s is still used by container apps %s, point them to another image first", result.Image, strings.Join(referencingContainerApps, ", "))
+	}
+
+	operationID, deleteErr := c.deleteImage(projectID, registry.ID, repositoryName, tagOrDigest, isDigest, credentials)
+	result.OperationID = operationID
+
+	record := domain.AuditRecord{
+		Time:         time.Now().UTC(),
+		Action:       domain.DestructiveActionDeleteImage,
+		ProjectID:    projectID,
+		RegistryName: registryName,
+		Image:        result.Image,
+		OperationID:  operationID,
+	}
+	if deleteErr != nil {
+		record.Error = deleteErr.Error()
+	}
+	if err := c.guardrails.RecordDestructiveAction(record); err != nil {
+		log.Printf("DeleteImage - failed to record deletion of %s: %v", result.Image, err)
+	}
+	if deleteErr != nil {
+		return nil, deleteErr
+	}
+
+	return result, nil
+}
+
+// deleteImage deletes a tag or a digest from a repository in Cloud.ru
+func (c *ContainerAppsApplication) deleteImage(projectID string, registryID string, repositoryName string, tagOrDigest string, isDigest bool, credentials domain.Credentials) (string, error) {
+	// Get access token using KEY_ID and KEY_SECRET
+	token, err := c.getAccessToken(credentials.KeyID, credentials.KeySecret)
+	if err != nil {
+		return "", fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	kind := "tags"
+	if isDigest {
+		kind = "digests"
+	}
+	requestURL := fmt.Sprintf("https://ar.api.cloud.ru/v1/projects/%s/registries/%s/repositories/%s/%s/%s", projectID, registryID, url.PathEscape(repositoryName), kind, url.PathEscape(tagOrDigest))
+	statusCode, body, err := c.doAPIRequest("DELETE", requestURL, token, nil)
+	if err != nil {
+		return "", err
+	}
+
+	// Log the response for debugging
+	log.Printf("DeleteImage response - Status: %d, Body length: %d, Body: %s", statusCode, len(body), string(body))
+
+	if statusCode != http.StatusNoContent && statusCode != http.StatusOK && statusCode != http.StatusAccepted {
+		return "", &domain.APIError{StatusCode: statusCode, Body: string(body)}
+	}
+
+	return operationIDFromResponse(body), nil
+}
@@ -0,0 +1,165 @@
+package application
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+)
+
+// ApplyRetentionPolicy deletes the images the retention policy selects from every repository of the registry.
+// The policy isn't stored in the registry: it is applied once with the same image deletion as DeleteImage,
+// and every deleted image is recorded in the audit log. Like DeleteImage, it refuses to delete images still used
+// by Container Apps of the project, or to run when the effect can't be fully checked, unless force is set.
+func (c *ContainerAppsApplication) ApplyRetentionPolicy(projectID string, registryName string, retentionPolicy domain.RetentionPolicy, force bool, credentials domain.Credentials) (*domain.RetentionResult, error) {
+	if err := retentionPolicy.Validate(); err != nil {
+		return nil, err
+	}
+	if err := c.guardrails.CheckRegistryDestructiveAction(domain.DestructiveActionApplyRetention, registryName); err != nil {
+		return nil, err
+	}
+
+	registry, err := c.findDockerRegistry(projectID, registryName, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	preview, err := c.previewRetentionPolicy(projectID, registry, retentionPolicy, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check the images the retention policy would delete: %w", err)
+	}
+	inUse := preview.InUse()
+	if !force {
+		if len(inUse) > 0 {
+			images := []string{}
+			for _, candidate := range inUse {
+				images = append(images, fmt.Sprintf("%s (used by %s)", retentionCandidateImage(registryName, candidate), strings.Join(candidate.InUseBy, ", ")))
+			}
+			return nil, fmt.Errorf("retention policy would delete images still used by container apps: %s. Protect their tags with protected tag patterns, point the apps to other images, or use force to apply the policy anyway", strings.Join(images, "; "))
+		}
+		if len(preview.Errors) > 0 {
+			return nil, fmt.Errorf("couldn't check the images of every repository (%s), use force to apply the retention policy anyway", strings.Join(preview.Errors, "; "))
+		}
+	}
+
+	result := &domain.RetentionResult{
+		RegistryName: registryName,
+		Policy:       retentionPolicy,
+		Deleted:      []domain.RetentionCandidate{},
+		Errors:       preview.Errors,
+	}
+	for _, candidate := range preview.Candidates {
+		// Untagged images can only be deleted by digest, tagged ones are deleted by tag so other tags of the digest survive
+		tagOrDigest, isDigest := candidate.Tag, false
+		if candidate.Tag == "" {
+			tagOrDigest, isDigest = candidate.Digest, true
+		}
+		operationID, deleteErr := c.deleteImage(projectID, registry.ID, candidate.Repository, tagOrDigest, isDigest, credentials)
+
+		image := retentionCandidateImage(registryName, candidate)
+		record := domain.AuditRecord{
+			Time:         time.Now().UTC(),
+			Action:       domain.DestructiveActionApplyRetention,
+			ProjectID:    projectID,
+			RegistryName: registryName,
+			Image:        image,
+			OperationID:  operationID,
+			// Force is only recorded when it overrode a safety check
+			Force: force && (len(inUse) > 0 || len(preview.Errors) > 0),
+		}
+		if deleteErr != nil {
+			record.Error = deleteErr.Error()
+		}
+		if err := c.guardrails.RecordDestructiveAction(record); err != nil {
+			log.Printf("ApplyRetentionPolicy - failed to record deletion of %s: %v", image, err)
+		}
+		if deleteErr != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", image, deleteErr))
+			continue
+		}
+		result.Deleted = append(result.Deleted, candidate)
+	}
+
+	return result, nil
+}
+
+// PreviewRetentionPolicy lists the images the retention policy would delete from every repository of the registry,
+// without changing anything. Images still used by Container Apps of the project are marked.
+func (c *ContainerAppsApplication) PreviewRetentionPolicy(projectID string, registryName string, retentionPolicy domain.RetentionPolicy, credentials domain.Credentials) (*domain.RetentionPreview, error) {
+	if err := retentionPolicy.Validate(); err != nil {
+		return nil, err
+	}
+
+	registry, err := c.findDockerRegistry(projectID, registryName, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.previewRetentionPolicy(projectID, registry, retentionPolicy, credentials)
+}
+
+// previewRetentionPolicy lists the images the retention policy would delete from an already resolved registry
+func (c *ContainerAppsApplication) previewRetentionPolicy(projectID string, registry *domain.DockerRegistry, retentionPolicy domain.RetentionPolicy, credentials domain.Credentials) (*domain.RetentionPreview, error) {
+	registryName := registry.Name
+
+	repositories, err := c.listRepositories(projectID, registry, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	containerApps, err := c.GetListContainerApps(projectID, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check container apps using registry %s: %w", registryName, err)
+	}
+
+	preview := &domain.RetentionPreview{
+		RegistryName: registryName,
+		Policy:       retentionPolicy,
+		Candidates:   []domain.RetentionCandidate{},
+	}
+	now := time.Now().UTC()
+	for _, repository := range repositories {
+		tags, err := c.listImageTags(projectID, registry, repository.Name, credentials)
+		if err != nil {
+			preview.Errors = append(preview.Errors, fmt.Sprintf("%s: %v", repository.Name, err))
+			continue
+		}
+
+		candidates := retentionPolicy.Evaluate(repository.Name, tags, now)
+		for _, candidate := range candidates {
+			candidate.InUseBy = containerAppsUsingCandidate(containerApps, registryName, candidate)
+			preview.Candidates = append(preview.Candidates, candidate)
+		}
+		preview.ReclaimedBytes += domain.ReclaimedBytes(tags, candidates)
+	}
+
+	return preview, nil
+}
+
+// retentionCandidateImage returns the full image reference of a candidate, by tag if it has one
+func retentionCandidateImage(registryName string, candidate domain.RetentionCandidate) string {
+	if candidate.Tag != "" {
+		return fmt.Sprintf("%s/%s:%s", domain.RegistryHost(registryName), candidate.Repository, candidate.Tag)
+	}
+	return fmt.Sprintf("%s/%s@%s", domain.RegistryHost(registryName), candidate.Repository, candidate.Digest)
+}
+
+// containerAppsUsingCandidate returns the names of the Container Apps that use the image by its tag or digest
+func containerAppsUsingCandidate(containerApps []domain.ContainerApp, registryName string, candidate domain.RetentionCandidate) []string {
+	names := []string{}
+	for _, containerApp := range containerApps {
+		for _, image := range containerApp.Images() {
+			reference := domain.ParseImageReference(image)
+			if reference.RegistryName() != registryName || reference.Repository != candidate.Repository {
+				continue
+			}
+			if (reference.Digest != "" && reference.Digest == candidate.Digest) || (candidate.Tag != "" && reference.EffectiveTag() == candidate.Tag) {
+				names = append(names, containerApp.Name)
+				break
+			}
+		}
+	}
+	return names
+}
@@ -0,0 +1,88 @@
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/config"
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+)
+
+// GuardrailsApplication implements the GuardrailsService interface.
+// Protected Container Apps and Docker Registries are configured by names or glob patterns,
+// destructive actions are appended to a JSON Lines file.
+type GuardrailsApplication struct {
+	protectedPatterns         []string
+	protectedRegistryPatterns []string
+	auditLogPath              string
+
+	mu sync.Mutex
+}
+
+// NewGuardrailsApplication creates a new GuardrailsApplication
+func NewGuardrailsApplication() domain.GuardrailsService {
+	cfg := config.LoadConfig()
+
+	return &GuardrailsApplication{
+		protectedPatterns:         cfg.ProtectedContainerApps,
+		protectedRegistryPatterns: cfg.ProtectedRegistries,
+		auditLogPath:              cfg.AuditLogPath,
+	}
+}
+
+// CheckDestructiveAction returns an error if the Container App is protected from destructive actions
+func (g *GuardrailsApplication) CheckDestructiveAction(action string, containerAppName string) error {
+	if pattern, ok := matchProtectedPattern(g.protectedPatterns, containerAppName); ok {
+		return fmt.Errorf("container app %s is protected by pattern %s in %s, %s is not allowed", containerAppName, pattern, config.EnvProtectedContainerApps, action)
+	}
+	return nil
+}
+
+// CheckRegistryDestructiveAction returns an error if the Docker Registry is protected from destructive actions
+func (g *GuardrailsApplication) CheckRegistryDestructiveAction(action string, registryName string) error {
+	if pattern, ok := matchProtectedPattern(g.protectedRegistryPatterns, registryName); ok {
+		return fmt.Errorf("docker registry %s is protected by pattern %s in %s, %s is not allowed", registryName, pattern, config.EnvProtectedRegistries, action)
+	}
+	return nil
+}
+
+// matchProtectedPattern returns the first pattern matching the name, either as a glob pattern or literally
+func matchProtectedPattern(patterns []string, name string) (string, bool) {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched || pattern == name {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
+// RecordDestructiveAction appends the record to the audit log
+func (g *GuardrailsApplication) RecordDestructiveAction(record domain.AuditRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit record: %w", err)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(g.auditLogPath), 0o700); err != nil {
+		return fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+
+	// The records contain full specifications including env values, so the file is only readable by the owner
+	file, err := os.OpenFile(g.auditLogPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log %s: %w", g.auditLogPath, err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log %s: %w", g.auditLogPath, err)
+	}
+	return nil
+}
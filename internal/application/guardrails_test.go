@@ -0,0 +1,28 @@
+package application
+
+import "testing"
+
+func TestGuardrailsCheckRegistryDestructiveAction(t *testing.T) {
+	guardrails := &GuardrailsApplication{
+		protectedPatterns:         []string{"registry-app"},
+		protectedRegistryPatterns: []string{"prod-*", "billing"},
+	}
+	tests := []struct {
+		name         string
+		registryName string
+		wantErr      bool
+	}{
+		{name: "glob pattern", registryName: "prod-images", wantErr: true},
+		{name: "exact name", registryName: "billing", wantErr: true},
+		{name: "not protected", registryName: "dev-images"},
+		{name: "container app patterns don't apply", registryName: "registry-app"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := guardrails.CheckRegistryDestructiveAction("delete registry", tt.registryName)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckRegistryDestructiveAction(%q) error = %v, wantErr %v", tt.registryName, err, tt.wantErr)
+			}
+		})
+	}
+}
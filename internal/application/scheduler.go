@@ -0,0 +1,483 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/config"
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+)
+
+const (
+	// schedulerCatchUpWindow is how late a rule still runs if its minute was missed, e.g. while the server was not running
+	schedulerCatchUpWindow = time.Hour
+	// schedulerConcurrency is how many Container Apps a schedule changes at the same time
+	schedulerConcurrency = 4
+	// schedulerLockWait is how long a tool call waits for another process to release the schedule files
+	schedulerLockWait = 10 * time.Second
+	// schedulerLockStaleAfter is when a lock file left behind by a crashed process is ignored,
+	// the lock is only held while the files are read and written
+	schedulerLockStaleAfter = time.Minute
+)
+
+// SchedulerApplication implements the SchedulerService interface.
+// Schedules are kept in a JSON file that can also be edited by hand, the last runs are kept in a separate state file.
+// Every MCP server process runs the scheduler, e.g. one per IDE window, so changes of the files are serialized
+// between processes by a lock file and a rule is claimed in the state file before it runs.
+type SchedulerApplication struct {
+	containerAppsService domain.ContainerAppsService
+	credentials          domain.Credentials
+	defaultProjectID     string
+	schedulesPath        string
+	statePath            string
+
+	mu sync.Mutex
+}
+
+// NewSchedulerApplication creates a new SchedulerApplication
+func NewSchedulerApplication(containerAppsService domain.ContainerAppsService) domain.SchedulerService {
+	cfg := config.LoadConfig()
+
+	return &SchedulerApplication{
+		containerAppsService: containerAppsService,
+		credentials: domain.Credentials{
+			KeyID:     cfg.KeyID,
+			KeySecret: cfg.KeySecret,
+		},
+		defaultProjectID: cfg.ProjectID,
+		schedulesPath:    cfg.SchedulesPath,
+		statePath:        cfg.SchedulerStatePath,
+	}
+}
+
+// ListSchedules returns all schedules with their last and next runs
+func (s *SchedulerApplication) ListSchedules() ([]domain.ScheduleStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schedules, err := s.loadSchedules()
+	if err != nil {
+		return nil, err
+	}
+	states, err := s.loadStates()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result := []domain.ScheduleStatus{}
+	for _, schedule := range schedules {
+		schedule.ProjectID = projectIDOrDefault(schedule.ProjectID, s.defaultProjectID)
+		status := domain.ScheduleStatus{
+			Schedule:      schedule,
+			ScheduleState: states[schedule.Name],
+		}
+		if location, err := schedule.Location(); err == nil {
+			status.NextStart = nextScheduleRun(schedule.StartCron, now.In(location))
+			status.NextStop = nextScheduleRun(schedule.StopCron, now.In(location))
+		}
+		result = append(result, status)
+	}
+	return result, nil
+}
+
+// AddSchedule validates the schedule and saves it
+func (s *SchedulerApplication) AddSchedule(schedule domain.Schedule) error {
+	schedule.ProjectID = projectIDOrDefault(schedule.ProjectID, s.defaultProjectID)
+	if err := schedule.Validate(); err != nil {
+		return err
+	}
+
+	unlock, err := s.lockFiles(schedulerLockWait)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	schedules, err := s.loadSchedules()
+	if err != nil {
+		return err
+	}
+	for _, existing := range schedules {
+		if existing.Name == schedule.Name {
+			return fmt.Errorf("schedule %s already exists, remove it first to replace it", schedule.Name)
+		}
+	}
+
+	return s.saveSchedules(append(schedules, schedule))
+}
+
+// RemoveSchedule deletes the schedule and its state
+func (s *SchedulerApplication) RemoveSchedule(name string) error {
+	unlock, err := s.lockFiles(schedulerLockWait)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	schedules, err := s.loadSchedules()
+	if err != nil {
+		return err
+	}
+
+	remaining := []domain.Schedule{}
+	for _, schedule := range schedules {
+		if schedule.Name != name {
+			remaining = append(remaining, schedule)
+		}
+	}
+	if len(remaining) == len(schedules) {
+		return fmt.Errorf("schedule %s not found in %s", name, s.schedulesPath)
+	}
+	if err := s.saveSchedules(remaining); err != nil {
+		return err
+	}
+
+	states, err := s.loadStates()
+	if err != nil {
+		return err
+	}
+	delete(states, name)
+	return s.saveStates(states)
+}
+
+// Run checks the schedules at the start of every minute until the context is cancelled
+func (s *SchedulerApplication) Run(ctx context.Context) {
+	log.Printf("Scheduler started, schedules: %s, state: %s", s.schedulesPath, s.statePath)
+
+	for {
+		s.runDueSchedules(time.Now())
+
+		wait := time.Until(time.Now().Truncate(time.Minute).Add(time.Minute))
+		select {
+		case <-ctx.Done():
+			log.Println("Scheduler stopped")
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// scheduledAction is a rule of a schedule that is due to run
+type scheduledAction struct {
+	action      string
+	scheduledAt time.Time
+}
+
+// runDueSchedules runs every start and stop rule whose time has come and that hasn't run for that time yet
+func (s *SchedulerApplication) runDueSchedules(now time.Time) {
+	due, err := s.claimDueSchedules(now)
+	if err != nil {
+		log.Printf("Scheduler - %v", err)
+		return
+	}
+
+	for _, rule := range due {
+		run := s.runSchedule(rule.schedule, rule.scheduledAction)
+		if err := s.recordRun(rule.schedule.Name, run); err != nil {
+			log.Printf("Scheduler - failed to save state of schedule %s: %v", rule.schedule.Name, err)
+		}
+	}
+}
+
+// dueSchedule is a due rule together with its schedule
+type dueSchedule struct {
+	scheduledAction
+	schedule domain.Schedule
+}
+
+// claimDueSchedules finds the due rules and records them as started, so that other processes don't run them again.
+// The files are only locked while claiming, so the tools stay responsive while Container Apps change.
+// A rule whose process dies after claiming it is not retried.
+func (s *SchedulerApplication) claimDueSchedules(now time.Time) ([]dueSchedule, error) {
+	// Without waiting: if another process holds the lock, the rules are checked again in a minute
+	unlock, err := s.lockFiles(0)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	schedules, err := s.loadSchedules()
+	if err != nil {
+		return nil, err
+	}
+	states, err := s.loadStates()
+	if err != nil {
+		return nil, err
+	}
+
+	result := []dueSchedule{}
+	for _, schedule := range schedules {
+		schedule.ProjectID = projectIDOrDefault(schedule.ProjectID, s.defaultProjectID)
+		if err := schedule.Validate(); err != nil {
+			log.Printf("Scheduler - skipping invalid schedule: %v", err)
+			continue
+		}
+		location, _ := schedule.Location()
+		state := states[schedule.Name]
+
+		due := []scheduledAction{}
+		if at := dueScheduleTime(schedule.StartCron, state.LastStart, now.In(location)); !at.IsZero() {
+			due = append(due, scheduledAction{action: domain.BulkActionStart, scheduledAt: at})
+			state.LastStart = &domain.ScheduleRun{Action: domain.BulkActionStart, ScheduledAt: at, StartedAt: now}
+		}
+		if at := dueScheduleTime(schedule.StopCron, state.LastStop, now.In(location)); !at.IsZero() {
+			due = append(due, scheduledAction{action: domain.BulkActionStop, scheduledAt: at})
+			state.LastStop = &domain.ScheduleRun{Action: domain.BulkActionStop, ScheduledAt: at, StartedAt: now}
+		}
+		if len(due) == 0 {
+			continue
+		}
+		// If both rules are due after a pause, the later one decides the final state
+		sort.Slice(due, func(i, j int) bool {
+			return due[i].scheduledAt.Before(due[j].scheduledAt)
+		})
+
+		states[schedule.Name] = state
+		for _, rule := range due {
+			result = append(result, dueSchedule{scheduledAction: rule, schedule: schedule})
+		}
+	}
+
+	if len(result) == 0 {
+		return result, nil
+	}
+	if err := s.saveStates(states); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// runSchedule applies the action to the Container Apps of the schedule that are not in the wanted state yet
+func (s *SchedulerApplication) runSchedule(schedule domain.Schedule, rule scheduledAction) domain.ScheduleRun {
+	run := domain.ScheduleRun{
+		Action:      rule.action,
+		ScheduledAt: rule.scheduledAt,
+		StartedAt:   time.Now(),
+	}
+
+	currentStatus := domain.ContainerAppStatusStopped
+	if rule.action == domain.BulkActionStop {
+		currentStatus = domain.ContainerAppStatusRunning
+	}
+	filter, err := schedule.Selector.Filter(currentStatus)
+	if err != nil {
+		run.Error = err.Error()
+		return run
+	}
+
+	result, err := s.containerAppsService.BulkContainerAppsAction(schedule.ProjectID, rule.action, filter, false, schedulerConcurrency, nil, s.credentials)
+	if err != nil {
+		run.Error = err.Error()
+		log.Printf("Scheduler - schedule %s failed to %s container apps: %v", schedule.Name, rule.action, err)
+		return run
+	}
+
+	for _, item := range result.Items {
+		if item.Error != "" {
+			run.Failed = append(run.Failed, fmt.Sprintf("%s: %s", item.Name, item.Error))
+		} else {
+			run.Succeeded = append(run.Succeeded, item.Name)
+		}
+	}
+	log.Printf("Scheduler - schedule %s: %s succeeded for %d container apps, failed for %d", schedule.Name, rule.action, len(run.Succeeded), len(run.Failed))
+
+	return run
+}
+
+// recordRun saves the run as the last run of its rule
+func (s *SchedulerApplication) recordRun(name string, run domain.ScheduleRun) error {
+	unlock, err := s.lockFiles(schedulerLockWait)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	states, err := s.loadStates()
+	if err != nil {
+		return err
+	}
+	state := states[name]
+	if run.Action == domain.BulkActionStart {
+		state.LastStart = &run
+	} else {
+		state.LastStop = &run
+	}
+	states[name] = state
+	return s.saveStates(states)
+}
+
+// dueScheduleTime returns the latest minute within the catch-up window that matches the expression
+// and is later than the last run, or the zero time if the rule isn't due
+func dueScheduleTime(expression string, lastRun *domain.ScheduleRun, now time.Time) time.Time {
+	if expression == "" {
+		return time.Time{}
+	}
+	cron, err := domain.ParseCronExpression(expression)
+	if err != nil {
+		return time.Time{}
+	}
+
+	for t := now.Truncate(time.Minute); now.Sub(t) < schedulerCatchUpWindow; t = t.Add(-time.Minute) {
+		if lastRun != nil && !t.After(lastRun.ScheduledAt) {
+			break
+		}
+		// When clocks move back for DST a wall-clock minute repeats, the rule only runs the first time
+		if lastRun != nil && t.Format("2006-01-02 15:04") == lastRun.ScheduledAt.In(t.Location()).Format("2006-01-02 15:04") {
+			continue
+		}
+		if cron.Matches(t) {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// nextScheduleRun returns the next time the expression matches, or nil if it never does
+func nextScheduleRun(expression string, now time.Time) *time.Time {
+	if expression == "" {
+		return nil
+	}
+	cron, err := domain.ParseCronExpression(expression)
+	if err != nil {
+		return nil
+	}
+	next := cron.Next(now)
+	if next.IsZero() {
+		return nil
+	}
+	return &next
+}
+
+// projectIDOrDefault returns the project ID, falling back to the default one
+func projectIDOrDefault(projectID string, defaultProjectID string) string {
+	if projectID == "" {
+		return defaultProjectID
+	}
+	return projectID
+}
+
+// lockFiles serializes changes of the schedule and state files within the process and between processes.
+// It waits up to the given time for another process to release the lock and returns the function releasing it.
+func (s *SchedulerApplication) lockFiles(wait time.Duration) (func(), error) {
+	s.mu.Lock()
+
+	lockPath := s.statePath + ".lock"
+	if err := os.MkdirAll(filepath.Dir(lockPath), 0o700); err != nil {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("failed to lock scheduler files: %w", err)
+	}
+
+	deadline := time.Now().Add(wait)
+	for {
+		file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			fmt.Fprintf(file, "%d\n", os.Getpid())
+			file.Close()
+			return func() {
+				if err := os.Remove(lockPath); err != nil {
+					log.Printf("Scheduler - failed to release lock %s: %v", lockPath, err)
+				}
+				s.mu.Unlock()
+			}, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			s.mu.Unlock()
+			return nil, fmt.Errorf("failed to lock scheduler files: %w", err)
+		}
+
+		if info, err := os.Stat(lockPath); err == nil && time.Since(info.ModTime()) > schedulerLockStaleAfter {
+			log.Printf("Scheduler - removing stale lock %s", lockPath)
+			os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			s.mu.Unlock()
+			return nil, fmt.Errorf("schedules are being changed by another process, try again later (lock file %s)", lockPath)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// loadSchedules reads the schedules file, a missing file means no schedules
+func (s *SchedulerApplication) loadSchedules() ([]domain.Schedule, error) {
+	schedules := []domain.Schedule{}
+	if err := readJSONFile(s.schedulesPath, &schedules); err != nil {
+		return nil, fmt.Errorf("failed to read schedules: %w", err)
+	}
+	return schedules, nil
+}
+
+// saveSchedules writes the schedules file
+func (s *SchedulerApplication) saveSchedules(schedules []domain.Schedule) error {
+	if err := writeJSONFile(s.schedulesPath, schedules); err != nil {
+		return fmt.Errorf("failed to save schedules: %w", err)
+	}
+	return nil
+}
+
+// loadStates reads the scheduler state file, a missing file means no runs yet
+func (s *SchedulerApplication) loadStates() (map[string]domain.ScheduleState, error) {
+	states := map[string]domain.ScheduleState{}
+	if err := readJSONFile(s.statePath, &states); err != nil {
+		return nil, fmt.Errorf("failed to read scheduler state: %w", err)
+	}
+	return states, nil
+}
+
+// saveStates writes the scheduler state file
+func (s *SchedulerApplication) saveStates(states map[string]domain.ScheduleState) error {
+	if err := writeJSONFile(s.statePath, states); err != nil {
+		return fmt.Errorf("failed to save scheduler state: %w", err)
+	}
+	return nil
+}
+
+// readJSONFile decodes a JSON file into value, leaving value unchanged if the file doesn't exist
+func readJSONFile(path string, value interface{}) error {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(content, value); err != nil {
+		return fmt.Errorf("invalid JSON in %s: %w", path, err)
+	}
+	return nil
+}
+
+// writeJSONFile encodes value into a JSON file readable only by the owner.
+// The file is replaced atomically, so a reader never sees a partially written file.
+func writeJSONFile(path string, value interface{}) error {
+	content, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(append(content, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
@@ -0,0 +1,107 @@
+package application
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+)
+
+func TestDueScheduleTime(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+	runAt := func(scheduledAt time.Time) *domain.ScheduleRun {
+		return &domain.ScheduleRun{ScheduledAt: scheduledAt}
+	}
+
+	tests := []struct {
+		name       string
+		expression string
+		lastRun    *domain.ScheduleRun
+		now        time.Time
+		want       time.Time
+	}{
+		{name: "no rule", expression: "", now: time.Date(2026, 6, 1, 20, 0, 0, 0, time.UTC), want: time.Time{}},
+		{name: "invalid rule", expression: "0 0 31 2 *", now: time.Date(2026, 6, 1, 20, 0, 0, 0, time.UTC), want: time.Time{}},
+		{name: "due this minute", expression: "0 20 * * *", now: time.Date(2026, 6, 1, 20, 0, 30, 0, time.UTC), want: time.Date(2026, 6, 1, 20, 0, 0, 0, time.UTC)},
+		{name: "not due yet", expression: "0 20 * * *", now: time.Date(2026, 6, 1, 19, 59, 0, 0, time.UTC), want: time.Time{}},
+		{name: "missed within the catch-up window", expression: "0 20 * * *", now: time.Date(2026, 6, 1, 20, 59, 0, 0, time.UTC), want: time.Date(2026, 6, 1, 20, 0, 0, 0, time.UTC)},
+		{name: "missed beyond the catch-up window", expression: "0 20 * * *", now: time.Date(2026, 6, 1, 21, 0, 0, 0, time.UTC), want: time.Time{}},
+		{name: "already run", expression: "0 20 * * *", lastRun: runAt(time.Date(2026, 6, 1, 20, 0, 0, 0, time.UTC)), now: time.Date(2026, 6, 1, 20, 30, 0, 0, time.UTC), want: time.Time{}},
+		{name: "run the day before", expression: "0 20 * * *", lastRun: runAt(time.Date(2026, 5, 31, 20, 0, 0, 0, time.UTC)), now: time.Date(2026, 6, 1, 20, 0, 0, 0, time.UTC), want: time.Date(2026, 6, 1, 20, 0, 0, 0, time.UTC)},
+		{name: "latest missed minute", expression: "*/15 * * * *", now: time.Date(2026, 6, 1, 20, 40, 0, 0, time.UTC), want: time.Date(2026, 6, 1, 20, 30, 0, 0, time.UTC)},
+		{name: "weekday step", expression: "0 20 * * */2", now: time.Date(2026, 6, 2, 20, 0, 0, 0, time.UTC), want: time.Date(2026, 6, 2, 20, 0, 0, 0, time.UTC)},
+		{name: "weekday step miss", expression: "0 20 * * */2", now: time.Date(2026, 6, 1, 20, 0, 0, 0, time.UTC), want: time.Time{}},
+		{name: "sunday as 7", expression: "0 20 * * 7", now: time.Date(2026, 6, 7, 20, 0, 0, 0, time.UTC), want: time.Date(2026, 6, 7, 20, 0, 0, 0, time.UTC)},
+		// On 2026-03-29 clocks in Berlin jump from 02:00 to 03:00, the skipped minute is not caught up
+		{name: "minute skipped by DST", expression: "30 2 * * *", now: time.Date(2026, 3, 29, 3, 30, 0, 0, berlin), want: time.Time{}},
+		// On 2026-10-25 clocks in Berlin go back from 03:00 to 02:00, the repeated minute only runs once
+		{name: "first repeated minute", expression: "30 2 * * *", now: time.Date(2026, 10, 25, 0, 30, 0, 0, time.UTC).In(berlin), want: time.Date(2026, 10, 25, 0, 30, 0, 0, time.UTC)},
+		{name: "second repeated minute", expression: "30 2 * * *", lastRun: runAt(time.Date(2026, 10, 25, 0, 30, 0, 0, time.UTC)), now: time.Date(2026, 10, 25, 1, 30, 0, 0, time.UTC).In(berlin), want: time.Time{}},
+		{name: "second repeated minute without a run", expression: "30 2 * * *", now: time.Date(2026, 10, 25, 1, 30, 0, 0, time.UTC).In(berlin), want: time.Date(2026, 10, 25, 1, 30, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dueScheduleTime(tt.expression, tt.lastRun, tt.now); !got.Equal(tt.want) {
+				t.Fatalf("dueScheduleTime(%q, %v) = %s, want %s", tt.expression, tt.now, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClaimDueSchedulesOnce(t *testing.T) {
+	dir := t.TempDir()
+	newScheduler := func() *SchedulerApplication {
+		return &SchedulerApplication{
+			defaultProjectID: "project",
+			schedulesPath:    filepath.Join(dir, "schedules.json"),
+			statePath:        filepath.Join(dir, "scheduler_state.json"),
+		}
+	}
+	first, second := newScheduler(), newScheduler()
+
+	schedule := domain.Schedule{
+		Name:     "nightly",
+		Selector: domain.ScheduleSelector{NamePattern: "dev-*"},
+		StopCron: "0 20 * * *",
+		Timezone: "UTC",
+	}
+	if err := first.AddSchedule(schedule); err != nil {
+		t.Fatalf("AddSchedule() error = %v", err)
+	}
+
+	// Two processes sharing the files must not both run the rule
+	now := time.Date(2026, 6, 1, 20, 0, 30, 0, time.UTC)
+	due, err := first.claimDueSchedules(now)
+	if err != nil {
+		t.Fatalf("claimDueSchedules() error = %v", err)
+	}
+	if len(due) != 1 || due[0].action != domain.BulkActionStop {
+		t.Fatalf("claimDueSchedules() = %+v, want one stop", due)
+	}
+	due, err = second.claimDueSchedules(now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("claimDueSchedules() error = %v", err)
+	}
+	if len(due) != 0 {
+		t.Fatalf("claimDueSchedules() in the second process = %+v, want nothing", due)
+	}
+
+	// A held lock makes the other process skip the minute instead of waiting
+	unlock, err := first.lockFiles(0)
+	if err != nil {
+		t.Fatalf("lockFiles() error = %v", err)
+	}
+	if _, err := second.claimDueSchedules(now.Add(24 * time.Hour)); err == nil {
+		t.Fatalf("claimDueSchedules() with a held lock succeeded, want an error")
+	}
+	unlock()
+	due, err = second.claimDueSchedules(now.Add(24 * time.Hour))
+	if err != nil || len(due) != 1 {
+		t.Fatalf("claimDueSchedules() after unlock = %+v, %v, want one stop", due, err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -20,6 +21,13 @@ type Config struct {
 	ProjectID        string
 	ContainerAppName string
 	CurrentDir       string
+
+	ProtectedContainerApps []string
+	ProtectedRegistries    []string
+	AuditLogPath           string
+
+	SchedulesPath      string
+	SchedulerStatePath string
 }
 
 // EnvVarNames contains the names of environment variables
@@ -33,6 +41,13 @@ const (
 	Dockerfile          = "CLOUDRU_DOCKERFILE"
 	DockerfileTarget    = "CLOUDRU_DOCKERFILE_TARGET"
 	DockerfileFolder    = "CLOUDRU_DOCKERFILE_FOLDER"
+
+	EnvProtectedContainerApps = "CLOUDRU_PROTECTED_CONTAINERAPPS"
+	EnvProtectedRegistries    = "CLOUDRU_PROTECTED_REGISTRIES"
+	EnvAuditLog               = "CLOUDRU_AUDIT_LOG"
+
+	EnvSchedules      = "CLOUDRU_SCHEDULES"
+	EnvSchedulerState = "CLOUDRU_SCHEDULER_STATE"
 )
 
 // LoadConfig loads configuration from environment variables and .env file
@@ -62,6 +77,21 @@ You will need a Key ID and Key Secret to use this service.`)
 	}
 	projectDirName := filepath.Base(dir)
 
+	auditLogPath := os.Getenv(EnvAuditLog)
+	if auditLogPath == "" {
+		auditLogPath = defaultDataFilePath("audit.jsonl")
+	}
+
+	schedulesPath := os.Getenv(EnvSchedules)
+	if schedulesPath == "" {
+		schedulesPath = defaultDataFilePath("schedules.json")
+	}
+
+	schedulerStatePath := os.Getenv(EnvSchedulerState)
+	if schedulerStatePath == "" {
+		schedulerStatePath = defaultDataFilePath("scheduler_state.json")
+	}
+
 	return &Config{
 		RegistryName:     os.Getenv(EnvRegistryName),
 		KeyID:            keyID,
@@ -73,5 +103,33 @@ You will need a Key ID and Key Secret to use this service.`)
 		DockerfileTarget: os.Getenv(DockerfileTarget),
 		DockerfileFolder: os.Getenv(DockerfileFolder),
 		CurrentDir:       projectDirName,
+
+		ProtectedContainerApps: splitList(os.Getenv(EnvProtectedContainerApps)),
+		ProtectedRegistries:    splitList(os.Getenv(EnvProtectedRegistries)),
+		AuditLogPath:           auditLogPath,
+
+		SchedulesPath:      schedulesPath,
+		SchedulerStatePath: schedulerStatePath,
+	}
+}
+
+// defaultDataFilePath returns the location of a local data file of the MCP in the user's home directory
+func defaultDataFilePath(fileName string) string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = "."
+	}
+	return filepath.Join(home, ".cloudru-containerapps-mcp", fileName)
+}
+
+// splitList splits a comma-separated environment variable value into trimmed non-empty items
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
 	}
+	return items
 }
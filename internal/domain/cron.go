@@ -0,0 +1,177 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxCronLookahead limits how far CronExpression.Next searches for the next matching minute
+const MaxCronLookahead = 366 * 24 * time.Hour
+
+var (
+	cronMonthNames   = []string{"", "jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+	cronWeekdayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+	// cronMonthDays is the longest length of every month, February counts leap years
+	cronMonthDays = []int{0, 31, 29, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
+)
+
+// CronExpression is a parsed five-field cron expression: minute, hour, day of month, month and day of week.
+// Fields support *, numbers, ranges (1-5), lists (1,3,5), steps (*/15, 8-18/2) and three-letter month and weekday names.
+// Expressions are matched against the wall clock: a minute skipped when clocks move forward for DST doesn't match,
+// a minute repeated when they move back matches twice.
+type CronExpression struct {
+	minutes  []bool
+	hours    []bool
+	days     []bool
+	months   []bool
+	weekdays []bool
+	// Like in cron, if both day of month and day of week are restricted, a day matching either of them matches.
+	// A field starting with *, such as */2, is not restricted, then a day must match both fields.
+	anyDay     bool
+	anyWeekday bool
+}
+
+// ParseCronExpression parses a five-field cron expression such as "0 20 * * mon-fri"
+func ParseCronExpression(expression string) (*CronExpression, error) {
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields: minute hour day-of-month month day-of-week", expression)
+	}
+
+	cron := &CronExpression{
+		anyDay:     strings.HasPrefix(fields[2], "*"),
+		anyWeekday: strings.HasPrefix(fields[4], "*"),
+	}
+	var err error
+	if cron.minutes, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("cron expression %q: minute: %w", expression, err)
+	}
+	if cron.hours, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("cron expression %q: hour: %w", expression, err)
+	}
+	if cron.days, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("cron expression %q: day of month: %w", expression, err)
+	}
+	if cron.months, err = parseCronField(fields[3], 1, 12, cronMonthNames); err != nil {
+		return nil, fmt.Errorf("cron expression %q: month: %w", expression, err)
+	}
+	// 7 is accepted as Sunday as well
+	weekdays, err := parseCronField(fields[4], 0, 7, cronWeekdayNames)
+	if err != nil {
+		return nil, fmt.Errorf("cron expression %q: day of week: %w", expression, err)
+	}
+	weekdays[0] = weekdays[0] || weekdays[7]
+	cron.weekdays = weekdays[:7]
+
+	if !cron.hasPossibleDay() {
+		return nil, fmt.Errorf("cron expression %q never matches: none of the months has the given days of month", expression)
+	}
+
+	return cron, nil
+}
+
+// hasPossibleDay reports whether the day of month and month fields select at least one existing date, e.g. "31 2" doesn't
+func (c *CronExpression) hasPossibleDay() bool {
+	// A restricted day of week alone matches every week
+	if !c.anyDay && !c.anyWeekday {
+		return true
+	}
+	for month := 1; month <= 12; month++ {
+		if !c.months[month] {
+			continue
+		}
+		for day := 1; day <= cronMonthDays[month]; day++ {
+			if c.days[day] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseCronField parses one field of a cron expression into a lookup table indexed by value
+func parseCronField(field string, min int, max int, names []string) ([]bool, error) {
+	values := make([]bool, max+1)
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i != -1 {
+			var err error
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step < 1 {
+				return nil, fmt.Errorf("invalid step in %q", part)
+			}
+			part = part[:i]
+		}
+
+		from, to := min, max
+		if part != "*" {
+			bounds := strings.SplitN(part, "-", 2)
+			var err error
+			if from, err = parseCronValue(bounds[0], min, max, names); err != nil {
+				return nil, err
+			}
+			to = from
+			if len(bounds) == 2 {
+				if to, err = parseCronValue(bounds[1], min, max, names); err != nil {
+					return nil, err
+				}
+			} else if step > 1 {
+				// A single value with a step, like 5/15, runs from the value to the end of the range
+				to = max
+			}
+			if from > to {
+				return nil, fmt.Errorf("range %q must go from the smaller to the larger value", part)
+			}
+		}
+
+		for value := from; value <= to; value += step {
+			values[value] = true
+		}
+	}
+	return values, nil
+}
+
+// parseCronValue parses a number or a name of a cron field value and checks its range
+func parseCronValue(value string, min int, max int, names []string) (int, error) {
+	for i, name := range names {
+		if name != "" && strings.EqualFold(value, name) {
+			return i, nil
+		}
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", value)
+	}
+	if number < min || number > max {
+		return 0, fmt.Errorf("value %d must be between %d and %d", number, min, max)
+	}
+	return number, nil
+}
+
+// Matches reports whether the minute of the given time matches the expression, in the time's location
+func (c *CronExpression) Matches(t time.Time) bool {
+	if !c.minutes[t.Minute()] || !c.hours[t.Hour()] || !c.months[int(t.Month())] {
+		return false
+	}
+
+	dayMatches := c.days[t.Day()]
+	weekdayMatches := c.weekdays[int(t.Weekday())]
+	if c.anyDay || c.anyWeekday {
+		return dayMatches && weekdayMatches
+	}
+	return dayMatches || weekdayMatches
+}
+
+// Next returns the first matching minute after the given time, or the zero time if there is none within MaxCronLookahead
+func (c *CronExpression) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	deadline := after.Add(MaxCronLookahead)
+	for ; t.Before(deadline); t = t.Add(time.Minute) {
+		if c.Matches(t) {
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("failed to load location %s: %v", name, err)
+	}
+	return location
+}
+
+func TestParseCronExpression(t *testing.T) {
+	tests := []struct {
+		expression string
+		wantErr    bool
+	}{
+		{expression: "0 20 * * mon-fri"},
+		{expression: "*/15 8-18/2 1,15 jan-jun 0-6"},
+		{expression: "0 0 * * 7"},
+		{expression: "0 0 * * SUN"},
+		{expression: "0 0 29 2 *"},
+		{expression: "5/15 * * * *"},
+		{expression: "0 0 31 2 mon"},
+		{expression: "0 0 * *", wantErr: true},
+		{expression: "0 0 * * * *", wantErr: true},
+		{expression: "60 * * * *", wantErr: true},
+		{expression: "* 24 * * *", wantErr: true},
+		{expression: "* * 0 * *", wantErr: true},
+		{expression: "* * * 13 *", wantErr: true},
+		{expression: "* * * * 8", wantErr: true},
+		{expression: "* * * * sunday", wantErr: true},
+		{expression: "5-1 * * * *", wantErr: true},
+		{expression: "*/0 * * * *", wantErr: true},
+		{expression: "*/x * * * *", wantErr: true},
+		{expression: "0 0 31 2 *", wantErr: true},
+		{expression: "0 0 30,31 2 *", wantErr: true},
+		{expression: "0 0 31 apr,jun,sep,nov *", wantErr: true},
+		{expression: "0 0 31 2 */2", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			_, err := ParseCronExpression(tt.expression)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCronExpression(%q) error = %v, wantErr %v", tt.expression, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCronExpressionMatches(t *testing.T) {
+	// 2026-06-01 is a Monday, 2026-06-07 a Sunday
+	tests := []struct {
+		name       string
+		expression string
+		time       time.Time
+		want       bool
+	}{
+		{name: "every minute", expression: "* * * * *", time: time.Date(2026, 6, 1, 13, 37, 0, 0, time.UTC), want: true},
+		{name: "exact minute", expression: "30 20 * * *", time: time.Date(2026, 6, 1, 20, 30, 0, 0, time.UTC), want: true},
+		{name: "other minute", expression: "30 20 * * *", time: time.Date(2026, 6, 1, 20, 31, 0, 0, time.UTC), want: false},
+		{name: "seconds are ignored", expression: "30 20 * * *", time: time.Date(2026, 6, 1, 20, 30, 59, 0, time.UTC), want: true},
+		{name: "minute step", expression: "*/15 * * * *", time: time.Date(2026, 6, 1, 10, 45, 0, 0, time.UTC), want: true},
+		{name: "minute step miss", expression: "*/15 * * * *", time: time.Date(2026, 6, 1, 10, 50, 0, 0, time.UTC), want: false},
+		{name: "value with step", expression: "5/20 * * * *", time: time.Date(2026, 6, 1, 10, 45, 0, 0, time.UTC), want: true},
+		{name: "range with step", expression: "0 8-18/4 * * *", time: time.Date(2026, 6, 1, 16, 0, 0, 0, time.UTC), want: true},
+		{name: "range with step miss", expression: "0 8-18/4 * * *", time: time.Date(2026, 6, 1, 18, 0, 0, 0, time.UTC), want: false},
+		{name: "weekday range", expression: "0 20 * * mon-fri", time: time.Date(2026, 6, 1, 20, 0, 0, 0, time.UTC), want: true},
+		{name: "weekday range weekend", expression: "0 20 * * mon-fri", time: time.Date(2026, 6, 7, 20, 0, 0, 0, time.UTC), want: false},
+		{name: "sunday as 0", expression: "0 0 * * 0", time: time.Date(2026, 6, 7, 0, 0, 0, 0, time.UTC), want: true},
+		{name: "sunday as 7", expression: "0 0 * * 7", time: time.Date(2026, 6, 7, 0, 0, 0, 0, time.UTC), want: true},
+		{name: "sunday as name", expression: "0 0 * * sun", time: time.Date(2026, 6, 7, 0, 0, 0, 0, time.UTC), want: true},
+		{name: "range ending with 7", expression: "0 0 * * 5-7", time: time.Date(2026, 6, 7, 0, 0, 0, 0, time.UTC), want: true},
+		{name: "7 is not monday", expression: "0 0 * * 7", time: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), want: false},
+		{name: "month name", expression: "0 0 1 jun *", time: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), want: true},
+		{name: "month name miss", expression: "0 0 1 jul *", time: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), want: false},
+		// Both day fields restricted: either of them matches
+		{name: "day of month or weekday by day", expression: "0 0 15 * mon", time: time.Date(2026, 6, 15, 0, 0, 0, 0, time.UTC), want: true},
+		{name: "day of month or weekday by weekday", expression: "0 0 13 * mon", time: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), want: true},
+		{name: "day of month or weekday neither", expression: "0 0 13 * mon", time: time.Date(2026, 6, 2, 0, 0, 0, 0, time.UTC), want: false},
+		// A weekday step starting with * is not a restriction, so both fields must match
+		{name: "weekday step every other day", expression: "0 0 * * */2", time: time.Date(2026, 6, 2, 0, 0, 0, 0, time.UTC), want: true},
+		{name: "weekday step miss", expression: "0 0 * * */2", time: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), want: false},
+		{name: "weekday step and day of month", expression: "0 0 1 * */2", time: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), want: false},
+		{name: "weekday step and day of month both", expression: "0 0 2 * */2", time: time.Date(2026, 6, 2, 0, 0, 0, 0, time.UTC), want: true},
+		{name: "weekday step other day of month", expression: "0 0 1 * */2", time: time.Date(2026, 6, 2, 0, 0, 0, 0, time.UTC), want: false},
+		{name: "day of month step", expression: "0 0 */10 * mon", time: time.Date(2026, 6, 21, 0, 0, 0, 0, time.UTC), want: false},
+		{name: "day of month step both", expression: "0 0 */10 * sun", time: time.Date(2026, 6, 21, 0, 0, 0, 0, time.UTC), want: true},
+		{name: "location wall clock", expression: "0 20 * * *", time: time.Date(2026, 6, 1, 20, 0, 0, 0, mustLoadLocation(t, "Europe/Moscow")), want: true},
+		{name: "location wall clock in UTC", expression: "0 20 * * *", time: time.Date(2026, 6, 1, 20, 0, 0, 0, mustLoadLocation(t, "Europe/Moscow")).UTC(), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCronExpression(tt.expression)
+			if err != nil {
+				t.Fatalf("ParseCronExpression(%q) error = %v", tt.expression, err)
+			}
+			if got := cron.Matches(tt.time); got != tt.want {
+				t.Fatalf("Matches(%s) = %v, want %v", tt.time, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronExpressionNext(t *testing.T) {
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+	tests := []struct {
+		name       string
+		expression string
+		after      time.Time
+		want       time.Time
+	}{
+		{name: "later today", expression: "0 20 * * *", after: time.Date(2026, 6, 1, 10, 0, 0, 0, time.UTC), want: time.Date(2026, 6, 1, 20, 0, 0, 0, time.UTC)},
+		{name: "strictly after", expression: "0 20 * * *", after: time.Date(2026, 6, 1, 20, 0, 0, 0, time.UTC), want: time.Date(2026, 6, 2, 20, 0, 0, 0, time.UTC)},
+		{name: "within the minute", expression: "* * * * *", after: time.Date(2026, 6, 1, 20, 0, 30, 0, time.UTC), want: time.Date(2026, 6, 1, 20, 1, 0, 0, time.UTC)},
+		{name: "next monday", expression: "0 8 * * mon", after: time.Date(2026, 6, 2, 9, 0, 0, 0, time.UTC), want: time.Date(2026, 6, 8, 8, 0, 0, 0, time.UTC)},
+		{name: "next year", expression: "0 0 1 1 *", after: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), want: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "leap day beyond the lookahead", expression: "0 0 29 2 *", after: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), want: time.Time{}},
+		{name: "leap day within the lookahead", expression: "0 0 29 2 *", after: time.Date(2027, 6, 1, 0, 0, 0, 0, time.UTC), want: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{name: "31st skips short months", expression: "0 0 31 * *", after: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), want: time.Date(2026, 5, 31, 0, 0, 0, 0, time.UTC)},
+		// On 2026-03-29 clocks in Berlin jump from 02:00 to 03:00, so 02:30 doesn't exist that day
+		{name: "minute skipped by DST", expression: "30 2 * * *", after: time.Date(2026, 3, 29, 0, 0, 0, 0, berlin), want: time.Date(2026, 3, 30, 2, 30, 0, 0, berlin)},
+		{name: "after DST change forward", expression: "30 3 * * *", after: time.Date(2026, 3, 29, 0, 0, 0, 0, berlin), want: time.Date(2026, 3, 29, 3, 30, 0, 0, berlin)},
+		// On 2026-10-25 clocks in Berlin go back from 03:00 to 02:00, so 02:30 happens twice
+		{name: "first repeated minute", expression: "30 2 * * *", after: time.Date(2026, 10, 25, 0, 0, 0, 0, berlin), want: time.Date(2026, 10, 25, 0, 30, 0, 0, time.UTC)},
+		{name: "second repeated minute", expression: "30 2 * * *", after: time.Date(2026, 10, 25, 0, 30, 0, 0, time.UTC).In(berlin), want: time.Date(2026, 10, 25, 1, 30, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCronExpression(tt.expression)
+			if err != nil {
+				t.Fatalf("ParseCronExpression(%q) error = %v", tt.expression, err)
+			}
+			if got := cron.Next(tt.after); !got.Equal(tt.want) {
+				t.Fatalf("Next(%s) = %s, want %s", tt.after, got, tt.want)
+			}
+		})
+	}
+}
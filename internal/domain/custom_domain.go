@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// MaxDomainNameLength is the maximum length of a fully qualified domain name
+const MaxDomainNameLength = 253
+
+// Verification statuses of a custom domain
+const (
+	DomainVerificationPending  = "PENDING"
+	DomainVerificationVerified = "VERIFIED"
+)
+
+// Certificate statuses of a custom domain
+const (
+	CertificateStatusPending = "PENDING"
+	CertificateStatusIssued  = "ISSUED"
+)
+
+// domainLabelRegexp matches one label of a domain name
+var domainLabelRegexp = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+// CustomDomain is a hostname of the organization served by a Container App instead of its generated PublicUri
+type CustomDomain struct {
+	Name                 string      `json:"name"`
+	ContainerAppName     string      `json:"containerAppName,omitempty"`
+	VerificationStatus   string      `json:"verificationStatus"`
+	CertificateStatus    string      `json:"certificateStatus"`
+	CertificateExpiresAt string      `json:"certificateExpiresAt,omitempty"`
+	StatusMessage        string      `json:"statusMessage,omitempty"`
+	DNSRecords           []DNSRecord `json:"dnsRecords,omitempty"`
+	CreatedAt            string      `json:"createdAt,omitempty"`
+	OperationID          string      `json:"operationId,omitempty"`
+}
+
+// DNSRecord is a record that must be created at the DNS provider of a custom domain
+type DNSRecord struct {
+	Type    string `json:"type"`
+	Host    string `json:"host"`
+	Value   string `json:"value"`
+	Purpose string `json:"purpose"`
+}
+
+// IsReady reports whether the domain is verified and serves traffic with a valid certificate
+func (d CustomDomain) IsReady() bool {
+	return strings.EqualFold(d.VerificationStatus, DomainVerificationVerified) && strings.EqualFold(d.CertificateStatus, CertificateStatusIssued)
+}
+
+// ValidateDomainName checks a fully qualified domain name such as api.example.com
+func ValidateDomainName(name string) error {
+	if name == "" {
+		return fmt.Errorf("domain name must not be empty")
+	}
+	if len(name) > MaxDomainNameLength {
+		return fmt.Errorf("domain name %q is %d characters long, the maximum is %d", name, len(name), MaxDomainNameLength)
+	}
+
+	labels := strings.Split(name, ".")
+	if len(labels) < 2 {
+		return fmt.Errorf("domain name %q must be fully qualified, e.g. api.example.com", name)
+	}
+	for _, label := range labels {
+		if len(label) > 63 || !domainLabelRegexp.MatchString(label) {
+			return fmt.Errorf("domain name %q must consist of labels of up to 63 lowercase latin letters, digits and hyphens, not starting or ending with a hyphen", name)
+		}
+	}
+	return nil
+}
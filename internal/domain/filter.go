@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Visibility values of a Container App used for filtering
+const (
+	VisibilityPublic  = "public"
+	VisibilityPrivate = "private"
+)
+
+// ContainerAppFilter selects Container Apps by name, status, image, visibility and labels.
+// Empty criteria match every Container App.
+type ContainerAppFilter struct {
+	NamePattern    string
+	NameRegex      *regexp.Regexp
+	Status         string
+	ImageSubstring string
+	Visibility     string
+	// Labels must all be set on the Container App, an empty value only requires the label to exist
+	Labels map[string]string
+}
+
+// NewContainerAppFilter creates a ContainerAppFilter and validates its criteria
+func NewContainerAppFilter(namePattern string, nameRegex string, status string, imageSubstring string, visibility string, labelSelector string) (*ContainerAppFilter, error) {
+	filter := &ContainerAppFilter{
+		NamePattern:    namePattern,
+		Status:         status,
+		ImageSubstring: imageSubstring,
+		Visibility:     strings.ToLower(visibility),
+	}
+
+	if namePattern != "" {
+		if _, err := path.Match(namePattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid name pattern %s: %w", namePattern, err)
+		}
+	}
+
+	if nameRegex != "" {
+		compiled, err := regexp.Compile(nameRegex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid name regex %s: %w", nameRegex, err)
+		}
+		filter.NameRegex = compiled
+	}
+
+	if filter.Visibility != "" && filter.Visibility != VisibilityPublic && filter.Visibility != VisibilityPrivate {
+		return nil, fmt.Errorf("visibility must be %s or %s", VisibilityPublic, VisibilityPrivate)
+	}
+
+	labels, err := ParseLabelSelector(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+	filter.Labels = labels
+
+	return filter, nil
+}
+
+// ParseLabelSelector parses a comma-separated list of label requirements such as "team=billing,env=dev,owner".
+// A key without a value only requires the label to exist.
+func ParseLabelSelector(selector string) (map[string]string, error) {
+	if strings.TrimSpace(selector) == "" {
+		return nil, nil
+	}
+
+	labels := map[string]string{}
+	for _, requirement := range strings.Split(selector, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(requirement), "=")
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if key == "" {
+			return nil, fmt.Errorf("invalid label selector %q, expected key=value pairs separated by commas", selector)
+		}
+		labels[key] = value
+	}
+	if err := ValidateLabels(labels); err != nil {
+		return nil, fmt.Errorf("invalid label selector: %w", err)
+	}
+	return labels, nil
+}
+
+// IsEmpty reports whether the filter has no criteria and therefore matches every Container App
+func (f *ContainerAppFilter) IsEmpty() bool {
+	return f.NamePattern == "" && f.NameRegex == nil && f.Status == "" && f.ImageSubstring == "" && f.Visibility == "" && len(f.Labels) == 0
+}
+
+// SelectsByNameOrLabels reports whether the filter narrows Container Apps down by name or labels.
+// Status, image and visibility alone can match most of a project, so they are not enough for stop and delete.
+func (f *ContainerAppFilter) SelectsByNameOrLabels() bool {
+	return f.NamePattern != "" || f.NameRegex != nil || len(f.Labels) > 0
+}
+
+// Matches reports whether the Container App satisfies all criteria of the filter
+func (f *ContainerAppFilter) Matches(app ContainerApp) bool {
+	if f.NamePattern != "" {
+		if matched, _ := path.Match(f.NamePattern, app.Name); !matched {
+			return false
+		}
+	}
+
+	if f.NameRegex != nil && !f.NameRegex.MatchString(app.Name) {
+		return false
+	}
+
+	if f.Status != "" && !strings.EqualFold(f.Status, app.Status) {
+		return false
+	}
+
+	if f.ImageSubstring != "" {
+		found := false
+		for _, container := range app.Template.Containers {
+			if strings.Contains(container.Image, f.ImageSubstring) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	switch f.Visibility {
+	case VisibilityPublic:
+		if !app.Configuration.Ingress.PubliclyAccessible {
+			return false
+		}
+	case VisibilityPrivate:
+		if app.Configuration.Ingress.PubliclyAccessible {
+			return false
+		}
+	}
+
+	for key, value := range f.Labels {
+		actual, ok := app.Labels[key]
+		if !ok || (value != "" && actual != value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Filter returns the Container Apps that match the filter
+func (f *ContainerAppFilter) Filter(apps []ContainerApp) []ContainerApp {
+	result := []ContainerApp{}
+	for _, app := range apps {
+		if f.Matches(app) {
+			result = append(result, app)
+		}
+	}
+	return result
+}
@@ -0,0 +1,32 @@
+package domain
+
+import "testing"
+
+func TestContainerAppFilterSelectsByNameOrLabels(t *testing.T) {
+	tests := []struct {
+		name          string
+		namePattern   string
+		nameRegex     string
+		status        string
+		visibility    string
+		labelSelector string
+		want          bool
+	}{
+		{name: "name pattern", namePattern: "dev-*", want: true},
+		{name: "name regex", nameRegex: "^dev-", want: true},
+		{name: "labels", labelSelector: "env=dev", want: true},
+		{name: "status only", status: ContainerAppStatusRunning, want: false},
+		{name: "visibility only", visibility: "public", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := NewContainerAppFilter(tt.namePattern, tt.nameRegex, tt.status, "", tt.visibility, tt.labelSelector)
+			if err != nil {
+				t.Fatalf("NewContainerAppFilter() error = %v", err)
+			}
+			if got := filter.SelectsByNameOrLabels(); got != tt.want {
+				t.Fatalf("SelectsByNameOrLabels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
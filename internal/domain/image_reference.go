@@ -0,0 +1,68 @@
+package domain
+
+import "strings"
+
+// RegistryHostSuffix is the domain under which Cloud.ru Docker Registries are served
+const RegistryHostSuffix = ".cr.cloud.ru"
+
+// RegistryHost returns the host of a Cloud.ru Docker Registry, e.g. my-registry.cr.cloud.ru
+func RegistryHost(registryName string) string {
+	return registryName + RegistryHostSuffix
+}
+
+// ImageReference is an image reference split into its parts: [registry/]repository[:tag][@digest]
+type ImageReference struct {
+	Registry   string
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// ParseImageReference splits an image reference into its parts, it doesn't validate them.
+// Use ValidateImageReference to check the syntax.
+func ParseImageReference(image string) ImageReference {
+	reference := ImageReference{}
+	name := image
+	if i := strings.Index(name, "@"); i != -1 {
+		reference.Digest = name[i+1:]
+		name = name[:i]
+	}
+
+	// A colon after the last slash separates the tag, a colon before it belongs to the registry port
+	if i := strings.LastIndex(name, ":"); i != -1 && i > strings.LastIndex(name, "/") {
+		reference.Tag = name[i+1:]
+		name = name[:i]
+	}
+
+	reference.Repository = name
+	if i := strings.Index(name, "/"); i != -1 {
+		host := name[:i]
+		// The first component is a registry only if it looks like a host, otherwise it is a part of the repository path
+		if strings.ContainsAny(host, ".:") || host == "localhost" {
+			reference.Registry = host
+			reference.Repository = name[i+1:]
+		}
+	}
+	return reference
+}
+
+// RegistryName returns the name of the Cloud.ru Docker Registry the image is stored in, or "" for other registries
+func (r ImageReference) RegistryName() string {
+	if !strings.HasSuffix(r.Registry, RegistryHostSuffix) {
+		return ""
+	}
+	return strings.TrimSuffix(r.Registry, RegistryHostSuffix)
+}
+
+// IsImageDigest reports whether the value is an image digest such as sha256:<hex> rather than a tag
+func IsImageDigest(value string) bool {
+	return imageDigestRegexp.MatchString(value)
+}
+
+// EffectiveTag returns the tag of the reference, an image without a tag or digest refers to the latest tag
+func (r ImageReference) EffectiveTag() string {
+	if r.Tag == "" && r.Digest == "" {
+		return "latest"
+	}
+	return r.Tag
+}
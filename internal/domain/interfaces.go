@@ -1,5 +1,7 @@
 package domain
 
+import "context"
+
 // DescriptionService provides usage instructions for the MCP
 type DescriptionService interface {
 	GetDescription() string
@@ -15,14 +17,45 @@ type DockerService interface {
 type ContainerAppsService interface {
 	GetListContainerApps(projectID string, credentials Credentials) ([]ContainerApp, error)
 	GetContainerApp(projectID string, containerAppName string, credentials Credentials) (*ContainerApp, error)
-	CreateContainerApp(projectID string, containerAppName string, containerAppPort int, containerAppImage string, credentials Credentials) (*ContainerApp, error)
-	DeleteContainerApp(projectID string, containerAppName string, credentials Credentials) error
-	StartContainerApp(projectID string, containerAppName string, credentials Credentials) error
-	StopContainerApp(projectID string, containerAppName string, credentials Credentials) error
+	CreateContainerApp(projectID string, containerAppName string, containerAppPort int, containerAppImage string, options ContainerAppOptions, credentials Credentials) (*ContainerApp, error)
+	CreateOrUpdateContainerApp(projectID string, containerAppName string, containerAppPort int, containerAppImage string, options ContainerAppOptions, ifExists string, credentials Credentials) (*CreateResult, error)
+	UpdateContainerApp(projectID string, containerAppName string, options ContainerAppOptions, credentials Credentials) (*ContainerApp, error)
+	DeleteContainerApp(projectID string, containerAppName string, credentials Credentials) (string, error)
+	StartContainerApp(projectID string, containerAppName string, credentials Credentials) (string, error)
+	StopContainerApp(projectID string, containerAppName string, credentials Credentials) (string, error)
+	RestartContainerApp(ctx context.Context, projectID string, containerAppName string, credentials Credentials) (*RestartResult, error)
+	CloneContainerApp(projectID string, containerAppName string, options CloneOptions, credentials Credentials) (*CloneResult, error)
+	BulkContainerAppsAction(projectID string, action string, filter *ContainerAppFilter, dryRun bool, concurrency int, confirmedNames []string, credentials Credentials) (*BulkResult, error)
+	GetOperation(operationID string, credentials Credentials) (*Operation, error)
+	ExportContainerApp(projectID string, containerAppName string, format string, outputPath string, overwrite bool, credentials Credentials) (string, error)
+	ListCustomDomains(projectID string, containerAppName string, credentials Credentials) ([]CustomDomain, error)
+	AttachCustomDomain(projectID string, containerAppName string, domainName string, credentials Credentials) (*CustomDomain, error)
+	DetachCustomDomain(projectID string, containerAppName string, domainName string, credentials Credentials) (string, error)
 }
 
 // DockerRegistryService handles Cloud.ru Docker Registry API operations
 type DockerRegistryService interface {
 	GetListDockerRegistries(projectID string, credentials Credentials) ([]DockerRegistry, error)
 	CreateDockerRegistry(projectID string, registryName string, isPublic bool, credentials Credentials) (*DockerRegistry, error)
+	DeleteDockerRegistry(projectID string, registryName string, force bool, credentials Credentials) (*DeleteRegistryResult, error)
+	ListRepositories(projectID string, registryName string, credentials Credentials) ([]Repository, error)
+	ListImageTags(projectID string, registryName string, repositoryName string, credentials Credentials) ([]ImageTag, error)
+	DeleteImage(projectID string, registryName string, repositoryName string, tagOrDigest string, credentials Credentials) (*DeleteImageResult, error)
+	PreviewRetentionPolicy(projectID string, registryName string, retentionPolicy RetentionPolicy, credentials Credentials) (*RetentionPreview, error)
+	ApplyRetentionPolicy(projectID string, registryName string, retentionPolicy RetentionPolicy, force bool, credentials Credentials) (*RetentionResult, error)
+}
+
+// GuardrailsService protects Container Apps and Docker Registries from destructive operations and records them
+type GuardrailsService interface {
+	CheckDestructiveAction(action string, containerAppName string) error
+	CheckRegistryDestructiveAction(action string, registryName string) error
+	RecordDestructiveAction(record AuditRecord) error
+}
+
+// SchedulerService starts and stops Container Apps on schedules while the server is running
+type SchedulerService interface {
+	ListSchedules() ([]ScheduleStatus, error)
+	AddSchedule(schedule Schedule) error
+	RemoveSchedule(name string) error
+	Run(ctx context.Context)
 }
@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Runtime metrics of a Container App. No tool queries them yet: the Cloud.ru Monitoring metric names
+// and endpoint have to be confirmed against the Monitoring API documentation before a query is added.
+const (
+	MetricInstances = "instances"
+	MetricCPU       = "cpu"
+	MetricMemory    = "memory"
+	MetricRequests  = "requests"
+	MetricErrors    = "errors"
+)
+
+// MetricNames lists the metrics that can be queried, in the order they are reported
+var MetricNames = []string{MetricInstances, MetricCPU, MetricMemory, MetricRequests, MetricErrors}
+
+// Limits of a metrics query, so a single call can't request an unbounded amount of data points
+const (
+	MaxMetricsWindow     = 30 * 24 * time.Hour
+	MinMetricsStep       = time.Minute
+	MaxMetricsDataPoints = 11000
+)
+
+// MetricSeries is one metric aggregated over the time window
+type MetricSeries struct {
+	Metric string `json:"metric"`
+	Unit   string `json:"unit"`
+	// Points is the number of data points in the window, the aggregates are empty if there are none
+	Points int      `json:"points"`
+	Min    *float64 `json:"min,omitempty"`
+	Avg    *float64 `json:"avg,omitempty"`
+	Max    *float64 `json:"max,omitempty"`
+	P95    *float64 `json:"p95,omitempty"`
+	Error  string   `json:"error,omitempty"`
+}
+
+// ParseMetricNames parses a comma-separated list of metrics, an empty list means all metrics.
+// A metric listed several times is only queried once.
+func ParseMetricNames(value string) ([]string, error) {
+	if strings.TrimSpace(value) == "" {
+		return MetricNames, nil
+	}
+
+	metrics := []string{}
+	seen := map[string]bool{}
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		known := false
+		for _, metric := range MetricNames {
+			if metric == name {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown metric %q, available metrics: %s", name, strings.Join(MetricNames, ", "))
+		}
+		metrics = append(metrics, name)
+	}
+	return metrics, nil
+}
+
+// ValidateMetricsWindow checks that the window and the step of a metrics query are within the limits
+func ValidateMetricsWindow(window time.Duration, step time.Duration) error {
+	if window <= 0 || window > MaxMetricsWindow {
+		return fmt.Errorf("time window must be positive and at most %s", MaxMetricsWindow)
+	}
+	if step < MinMetricsStep {
+		return fmt.Errorf("step must be at least %s", MinMetricsStep)
+	}
+	if window/step > MaxMetricsDataPoints {
+		return fmt.Errorf("time window %s with step %s gives more than %d data points, increase the step", window, step, MaxMetricsDataPoints)
+	}
+	return nil
+}
+
+// AggregateMetric computes min, average, max and the 95th percentile of the values of a metric
+func AggregateMetric(metric string, unit string, values []float64) MetricSeries {
+	series := MetricSeries{
+		Metric: metric,
+		Unit:   unit,
+		Points: len(values),
+	}
+	if len(values) == 0 {
+		return series
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	sum := 0.0
+	for _, value := range sorted {
+		sum += value
+	}
+	min := sorted[0]
+	max := sorted[len(sorted)-1]
+	avg := sum / float64(len(sorted))
+	// Nearest-rank percentile: the smallest value with at least 95% of the values at or below it
+	p95 := sorted[int(math.Ceil(0.95*float64(len(sorted))))-1]
+
+	series.Min = &min
+	series.Avg = &avg
+	series.Max = &max
+	series.P95 = &p95
+	return series
+}
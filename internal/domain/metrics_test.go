@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseMetricNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{name: "empty means all", value: "", want: MetricNames},
+		{name: "blank means all", value: "  ", want: MetricNames},
+		{name: "single", value: "cpu", want: []string{MetricCPU}},
+		{name: "order is kept", value: "memory,cpu", want: []string{MetricMemory, MetricCPU}},
+		{name: "case and spaces", value: " CPU , Memory", want: []string{MetricCPU, MetricMemory}},
+		{name: "duplicates are dropped", value: "cpu,memory,CPU", want: []string{MetricCPU, MetricMemory}},
+		{name: "unknown", value: "cpu,disk", wantErr: true},
+		{name: "empty item", value: "cpu,", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMetricNames(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMetricNames(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("ParseMetricNames(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateMetricsWindow(t *testing.T) {
+	tests := []struct {
+		name    string
+		window  time.Duration
+		step    time.Duration
+		wantErr bool
+	}{
+		{name: "hour by minute", window: time.Hour, step: time.Minute},
+		{name: "maximum window", window: MaxMetricsWindow, step: time.Hour},
+		{name: "zero window", window: 0, step: time.Minute, wantErr: true},
+		{name: "window too long", window: MaxMetricsWindow + time.Hour, step: time.Hour, wantErr: true},
+		{name: "step too small", window: time.Hour, step: 30 * time.Second, wantErr: true},
+		{name: "too many data points", window: MaxMetricsWindow, step: time.Minute, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateMetricsWindow(tt.window, tt.step); (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateMetricsWindow(%s, %s) error = %v, wantErr %v", tt.window, tt.step, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAggregateMetric(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		min    float64
+		avg    float64
+		max    float64
+		p95    float64
+	}{
+		{name: "single value", values: []float64{3}, min: 3, avg: 3, max: 3, p95: 3},
+		{name: "unsorted", values: []float64{4, 1, 3, 2}, min: 1, avg: 2.5, max: 4, p95: 4},
+		// With 20 values the nearest rank of p95 is the 19th value
+		{name: "nearest rank", values: []float64{20, 19, 18, 17, 16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1}, min: 1, avg: 10.5, max: 20, p95: 19},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := append([]float64(nil), tt.values...)
+			series := AggregateMetric(MetricCPU, "cores", values)
+
+			if series.Metric != MetricCPU || series.Unit != "cores" || series.Points != len(tt.values) {
+				t.Fatalf("series = %+v, want metric %s, unit cores and %d points", series, MetricCPU, len(tt.values))
+			}
+			if series.Min == nil || series.Avg == nil || series.Max == nil || series.P95 == nil {
+				t.Fatalf("series = %+v, want all aggregates set", series)
+			}
+			got := []float64{*series.Min, *series.Avg, *series.Max, *series.P95}
+			want := []float64{tt.min, tt.avg, tt.max, tt.p95}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("min, avg, max, p95 = %v, want %v", got, want)
+			}
+			if !reflect.DeepEqual(values, tt.values) {
+				t.Fatalf("AggregateMetric() reordered the values to %v", values)
+			}
+		})
+	}
+}
+
+func TestAggregateMetricWithoutValues(t *testing.T) {
+	series := AggregateMetric(MetricMemory, "bytes", nil)
+	if series.Points != 0 || series.Min != nil || series.Avg != nil || series.Max != nil || series.P95 != nil {
+		t.Fatalf("series = %+v, want no points and no aggregates", series)
+	}
+}
@@ -0,0 +1,397 @@
+package domain
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+// Supported values of the Container App protocol
+const (
+	ProtocolHTTP1 = "http1"
+	ProtocolHTTP2 = "http2"
+	ProtocolGRPC  = "grpc"
+)
+
+// MaxRequestTimeout is the longest request and idle timeout a Container App can be configured with
+const MaxRequestTimeout = time.Hour
+
+// ContainerAppOptions holds optional settings applied when creating or updating a Container App.
+// Nil fields are left unchanged, so an empty non-nil slice clears the corresponding setting.
+type ContainerAppOptions struct {
+	PubliclyAccessible     *bool
+	AdditionalPortMappings []PortMapping
+	Volumes                []Volume
+	VolumeMounts           []VolumeMount
+	Command                []string
+	Args                   []string
+	InitContainers         []Container
+	AutoDeploymentsEnabled *bool
+	AutoDeploymentsPattern *string
+	Timeout                *time.Duration
+	IdleTimeout            *time.Duration
+	Protocol               *string
+	// Sidecars replace every container of the Container App except the main (first) one
+	Sidecars         []Container
+	IngressContainer *string
+	Description      *string
+	// Labels are merged into the current labels, a label with an empty value is removed
+	Labels map[string]string
+}
+
+// Validate checks the options that don't depend on the current state of the Container App,
+// so that invalid options are rejected before the Container App is fetched
+func (o ContainerAppOptions) Validate() error {
+	if o.Description != nil && len(*o.Description) > MaxDescriptionLength {
+		return fmt.Errorf("description is %d characters long, the maximum is %d", len(*o.Description), MaxDescriptionLength)
+	}
+
+	if o.Labels != nil {
+		if err := ValidateLabels(o.Labels); err != nil {
+			return err
+		}
+	}
+
+	seenPorts := map[int]bool{}
+	for _, mapping := range o.AdditionalPortMappings {
+		if mapping.Port < 1 || mapping.Port > 65535 {
+			return fmt.Errorf("additional port mapping port %d must be between 1 and 65535", mapping.Port)
+		}
+		if mapping.ContainerPort < 1 || mapping.ContainerPort > 65535 {
+			return fmt.Errorf("additional port mapping container port %d must be between 1 and 65535", mapping.ContainerPort)
+		}
+		if seenPorts[mapping.Port] {
+			return fmt.Errorf("additional port mapping port %d is declared more than once", mapping.Port)
+		}
+		seenPorts[mapping.Port] = true
+	}
+
+	if o.InitContainers != nil {
+		if err := validateInitContainers(o.InitContainers); err != nil {
+			return err
+		}
+	}
+
+	if o.AutoDeploymentsPattern != nil && *o.AutoDeploymentsPattern != "" {
+		if _, err := path.Match(*o.AutoDeploymentsPattern, ""); err != nil {
+			return fmt.Errorf("invalid auto-deployments tag pattern %s: %w", *o.AutoDeploymentsPattern, err)
+		}
+	}
+
+	if o.Timeout != nil {
+		if err := validateTimeout("timeout", *o.Timeout); err != nil {
+			return err
+		}
+	}
+
+	if o.IdleTimeout != nil {
+		if err := validateTimeout("idle timeout", *o.IdleTimeout); err != nil {
+			return err
+		}
+	}
+
+	if o.Protocol != nil {
+		switch *o.Protocol {
+		case ProtocolHTTP1, ProtocolHTTP2, ProtocolGRPC:
+		default:
+			return fmt.Errorf("protocol must be one of %s, %s or %s", ProtocolHTTP1, ProtocolHTTP2, ProtocolGRPC)
+		}
+	}
+
+	sidecarNames := map[string]bool{}
+	for _, sidecar := range o.Sidecars {
+		if sidecar.Name == "" {
+			return fmt.Errorf("sidecar container name must not be empty")
+		}
+		if sidecar.Image == "" {
+			return fmt.Errorf("sidecar container %s must specify an image", sidecar.Name)
+		}
+		if sidecarNames[sidecar.Name] {
+			return fmt.Errorf("sidecar container %s is declared more than once", sidecar.Name)
+		}
+		sidecarNames[sidecar.Name] = true
+		if err := ValidateContainer(sidecar); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ApplyTo validates the options and applies them to the given Container App
+func (o ContainerAppOptions) ApplyTo(app *ContainerApp) error {
+	if err := o.Validate(); err != nil {
+		return err
+	}
+
+	if o.Description != nil {
+		app.Description = *o.Description
+	}
+
+	if o.Labels != nil {
+		labels := map[string]string{}
+		for key, value := range app.Labels {
+			labels[key] = value
+		}
+		for key, value := range o.Labels {
+			if value == "" {
+				delete(labels, key)
+			} else {
+				labels[key] = value
+			}
+		}
+		app.Labels = labels
+	}
+
+	if o.PubliclyAccessible != nil {
+		app.Configuration.Ingress.PubliclyAccessible = *o.PubliclyAccessible
+	}
+
+	if o.AdditionalPortMappings != nil {
+		app.Configuration.Ingress.AdditionalPortMappings = o.AdditionalPortMappings
+	}
+
+	if o.Volumes != nil {
+		// The volumes are copied so that setting defaults doesn't change the caller's options
+		volumes := append([]Volume{}, o.Volumes...)
+		for i := range volumes {
+			// Object Storage buckets are the only supported volume type, so it can be omitted
+			if volumes[i].Type == "" {
+				volumes[i].Type = BucketVolumeType
+			}
+		}
+		app.Template.Volumes = volumes
+	}
+
+	if o.VolumeMounts != nil {
+		if len(app.Template.Containers) == 0 {
+			return fmt.Errorf("container app has no containers to mount volumes into")
+		}
+		app.Template.Containers[0].VolumeMounts = o.VolumeMounts
+	}
+
+	if o.Command != nil || o.Args != nil {
+		if len(app.Template.Containers) == 0 {
+			return fmt.Errorf("container app has no containers to set command and args for")
+		}
+		if o.Command != nil {
+			app.Template.Containers[0].Command = o.Command
+		}
+		if o.Args != nil {
+			app.Template.Containers[0].Args = o.Args
+		}
+	}
+
+	if o.InitContainers != nil {
+		app.Template.InitContainers = o.InitContainers
+	}
+
+	if o.AutoDeploymentsEnabled != nil || o.AutoDeploymentsPattern != nil {
+		autoDeployments := &app.Configuration.AutoDeployments
+		if o.AutoDeploymentsEnabled != nil {
+			autoDeployments.Enabled = *o.AutoDeploymentsEnabled
+		}
+		if o.AutoDeploymentsPattern != nil {
+			autoDeployments.Pattern = *o.AutoDeploymentsPattern
+		}
+		if err := validateAutoDeployments(*autoDeployments); err != nil {
+			return err
+		}
+	}
+
+	if o.Timeout != nil {
+		app.Template.Timeout = formatDuration(*o.Timeout)
+	}
+
+	if o.IdleTimeout != nil {
+		app.Template.IdleTimeout = formatDuration(*o.IdleTimeout)
+	}
+
+	if o.Protocol != nil {
+		app.Template.Protocol = *o.Protocol
+	}
+
+	if o.Sidecars != nil || o.IngressContainer != nil {
+		if len(app.Template.Containers) == 0 {
+			return fmt.Errorf("container app has no main container to add sidecars to")
+		}
+		if o.Sidecars != nil {
+			app.Template.Containers = append([]Container{app.Template.Containers[0]}, o.Sidecars...)
+		}
+		if o.IngressContainer != nil {
+			app.Configuration.Ingress.ContainerName = *o.IngressContainer
+		}
+		if err := validateContainers(app); err != nil {
+			return err
+		}
+	}
+
+	if o.Volumes != nil || o.VolumeMounts != nil || o.InitContainers != nil || o.Sidecars != nil {
+		if err := validateVolumes(app); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateTimeout checks that a timeout is a positive whole number of seconds within the platform limit
+func validateTimeout(name string, timeout time.Duration) error {
+	if timeout < time.Second || timeout > MaxRequestTimeout {
+		return fmt.Errorf("%s %s must be between 1s and %s", name, timeout, MaxRequestTimeout)
+	}
+	if timeout%time.Second != 0 {
+		return fmt.Errorf("%s %s must be a whole number of seconds", name, timeout)
+	}
+	return nil
+}
+
+// formatDuration converts a duration into the API format, a number of seconds with the "s" suffix
+func formatDuration(duration time.Duration) string {
+	return fmt.Sprintf("%ds", int64(duration/time.Second))
+}
+
+// validateAutoDeployments checks that enabled auto-deployments have a valid image tag pattern
+func validateAutoDeployments(autoDeployments AutoDeployments) error {
+	if !autoDeployments.Enabled {
+		return nil
+	}
+	if autoDeployments.Pattern == "" {
+		return fmt.Errorf("auto-deployments require an image tag pattern, for example v* or main-*")
+	}
+	if _, err := path.Match(autoDeployments.Pattern, ""); err != nil {
+		return fmt.Errorf("invalid auto-deployments tag pattern %s: %w", autoDeployments.Pattern, err)
+	}
+	return nil
+}
+
+// ValidateContainerAppSpec checks the containers, init containers and volumes of a complete specification,
+// e.g. one copied from another Container App
+func ValidateContainerAppSpec(app *ContainerApp) error {
+	if err := validateContainers(app); err != nil {
+		return err
+	}
+	if err := validateInitContainers(app.Template.InitContainers); err != nil {
+		return err
+	}
+	return validateVolumes(app)
+}
+
+// validateContainers checks that containers are uniquely named, don't listen on the same port
+// and that the ingress container is one of them
+func validateContainers(app *ContainerApp) error {
+	names := map[string]bool{}
+	ports := map[int]string{}
+	for _, container := range app.Template.Containers {
+		if container.Name == "" {
+			return fmt.Errorf("container name must not be empty")
+		}
+		if container.Image == "" {
+			return fmt.Errorf("container %s must specify an image", container.Name)
+		}
+		if names[container.Name] {
+			return fmt.Errorf("container %s is declared more than once", container.Name)
+		}
+		names[container.Name] = true
+		if err := ValidateContainer(container); err != nil {
+			return err
+		}
+
+		if container.ContainerPort == 0 {
+			continue
+		}
+		// Containers of an app share the network, so two of them can't listen on the same port
+		if other, ok := ports[container.ContainerPort]; ok {
+			return fmt.Errorf("containers %s and %s both use port %d", other, container.Name, container.ContainerPort)
+		}
+		ports[container.ContainerPort] = container.Name
+	}
+
+	ingressContainer := app.Configuration.Ingress.ContainerName
+	if ingressContainer == "" {
+		return nil
+	}
+	for _, container := range app.Template.Containers {
+		if container.Name == ingressContainer {
+			if container.ContainerPort == 0 {
+				return fmt.Errorf("ingress container %s must specify a container port", ingressContainer)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("ingress container %s is not one of the containers of the container app", ingressContainer)
+}
+
+// validateInitContainers checks that init containers are well-formed and uniquely named
+func validateInitContainers(initContainers []Container) error {
+	names := map[string]bool{}
+	for _, container := range initContainers {
+		if container.Name == "" {
+			return fmt.Errorf("init container name must not be empty")
+		}
+		if container.Image == "" {
+			return fmt.Errorf("init container %s must specify an image", container.Name)
+		}
+		if container.ContainerPort != 0 {
+			return fmt.Errorf("init container %s must not expose a container port", container.Name)
+		}
+		if names[container.Name] {
+			return fmt.Errorf("init container %s is declared more than once", container.Name)
+		}
+		names[container.Name] = true
+		if err := ValidateContainer(container); err != nil {
+			return fmt.Errorf("init %w", err)
+		}
+	}
+	return nil
+}
+
+// validateVolumes checks that volumes are well-formed and every container, including init containers, mounts them at distinct paths
+func validateVolumes(app *ContainerApp) error {
+	volumeNames := map[string]bool{}
+	for _, volume := range app.Template.Volumes {
+		if volume.Name == "" {
+			return fmt.Errorf("volume name must not be empty")
+		}
+		if volumeNames[volume.Name] {
+			return fmt.Errorf("volume %s is declared more than once", volume.Name)
+		}
+		volumeNames[volume.Name] = true
+
+		if volume.Type == BucketVolumeType && volume.VolumeAttributes.BucketName == "" {
+			return fmt.Errorf("volume %s must specify volumeAttributes.bucketName", volume.Name)
+		}
+	}
+
+	containers := append(append([]Container{}, app.Template.Containers...), app.Template.InitContainers...)
+	for _, container := range containers {
+		mountPaths := []string{}
+		for _, mount := range container.VolumeMounts {
+			if !volumeNames[mount.Name] {
+				return fmt.Errorf("volume mount %s in container %s refers to an undeclared volume", mount.Name, container.Name)
+			}
+			if !strings.HasPrefix(mount.MountPath, "/") {
+				return fmt.Errorf("mount path %s of volume %s must be absolute", mount.MountPath, mount.Name)
+			}
+
+			mountPath := path.Clean(mount.MountPath)
+			for _, otherPath := range mountPaths {
+				if mountPathsCollide(mountPath, otherPath) {
+					return fmt.Errorf("mount path %s of volume %s collides with mount path %s in container %s", mount.MountPath, mount.Name, otherPath, container.Name)
+				}
+			}
+			mountPaths = append(mountPaths, mountPath)
+		}
+	}
+
+	return nil
+}
+
+// mountPathsCollide reports whether two cleaned mount paths are equal or one is nested in the other
+func mountPathsCollide(a string, b string) bool {
+	if a == b || a == "/" || b == "/" {
+		return true
+	}
+	return strings.HasPrefix(a, b+"/") || strings.HasPrefix(b, a+"/")
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestContainerAppOptionsApplyToKeepsOptions(t *testing.T) {
+	options := ContainerAppOptions{
+		Volumes: []Volume{{Name: "data", VolumeAttributes: VolumeAttributes{BucketName: "bucket"}}},
+	}
+	app := &ContainerApp{}
+
+	if err := options.ApplyTo(app); err != nil {
+		t.Fatalf("ApplyTo() error = %v", err)
+	}
+	if app.Template.Volumes[0].Type != BucketVolumeType {
+		t.Fatalf("volume type = %q, want %q", app.Template.Volumes[0].Type, BucketVolumeType)
+	}
+	if options.Volumes[0].Type != "" {
+		t.Fatalf("ApplyTo() changed the volume type of the options to %q", options.Volumes[0].Type)
+	}
+}
+
+func TestContainerAppOptionsApplyToClearsDescription(t *testing.T) {
+	description := ""
+	app := &ContainerApp{Description: "old"}
+
+	if err := (ContainerAppOptions{Description: &description}).ApplyTo(app); err != nil {
+		t.Fatalf("ApplyTo() error = %v", err)
+	}
+	if app.Description != "" {
+		t.Fatalf("description = %q, want it cleared", app.Description)
+	}
+}
+
+func TestContainerAppOptionsValidate(t *testing.T) {
+	timeout := 30 * time.Second
+	fractionalTimeout := 1500 * time.Millisecond
+	longTimeout := 2 * MaxRequestTimeout
+	protocol := ProtocolGRPC
+	unknownProtocol := "http3"
+	pattern := "v*"
+	invalidPattern := "v["
+	tests := []struct {
+		name    string
+		options ContainerAppOptions
+		wantErr bool
+	}{
+		{name: "empty"},
+		{name: "valid", options: ContainerAppOptions{
+			Timeout:                &timeout,
+			Protocol:               &protocol,
+			AutoDeploymentsPattern: &pattern,
+			AdditionalPortMappings: []PortMapping{{Port: 9090, ContainerPort: 9090}},
+			Sidecars:               []Container{{Name: "proxy", Image: "envoyproxy/envoy:v1.30"}},
+		}},
+		{name: "fractional timeout", options: ContainerAppOptions{Timeout: &fractionalTimeout}, wantErr: true},
+		{name: "idle timeout too long", options: ContainerAppOptions{IdleTimeout: &longTimeout}, wantErr: true},
+		{name: "unknown protocol", options: ContainerAppOptions{Protocol: &unknownProtocol}, wantErr: true},
+		{name: "invalid auto-deployments pattern", options: ContainerAppOptions{AutoDeploymentsPattern: &invalidPattern}, wantErr: true},
+		{name: "duplicate port mapping", options: ContainerAppOptions{AdditionalPortMappings: []PortMapping{{Port: 9090, ContainerPort: 9090}, {Port: 9090, ContainerPort: 9091}}}, wantErr: true},
+		{name: "sidecar without image", options: ContainerAppOptions{Sidecars: []Container{{Name: "proxy"}}}, wantErr: true},
+		{name: "duplicate sidecar", options: ContainerAppOptions{Sidecars: []Container{{Name: "proxy", Image: "envoy"}, {Name: "proxy", Image: "envoy"}}}, wantErr: true},
+		{name: "invalid label", options: ContainerAppOptions{Labels: map[string]string{"Team": "billing"}}, wantErr: true},
+		{name: "init container with port", options: ContainerAppOptions{InitContainers: []Container{{Name: "migrate", Image: "migrate", ContainerPort: 8080}}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.options.Validate(); (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package domain
+
+import (
+	"fmt"
+	"path"
+	"time"
+)
+
+// RetentionPolicy decides which images of a Docker Registry are deleted automatically.
+// A tag is deleted only if every configured tag rule allows it: it is not among the last KeepLastTags
+// pushed tags of its repository and it is older than DeleteOlderThanDays. Tags matching ProtectedTagPatterns are never deleted.
+type RetentionPolicy struct {
+	KeepLastTags         int      `json:"keepLastTags,omitempty"`
+	DeleteUntagged       bool     `json:"deleteUntagged,omitempty"`
+	DeleteOlderThanDays  int      `json:"deleteOlderThanDays,omitempty"`
+	ProtectedTagPatterns []string `json:"protectedTagPatterns,omitempty"`
+}
+
+// Validate checks that the policy has at least one deletion rule and valid values
+func (p RetentionPolicy) Validate() error {
+	if p.KeepLastTags < 0 {
+		return fmt.Errorf("keep last tags must not be negative")
+	}
+	if p.DeleteOlderThanDays < 0 {
+		return fmt.Errorf("delete older than days must not be negative")
+	}
+	if p.KeepLastTags == 0 && p.DeleteOlderThanDays == 0 && !p.DeleteUntagged {
+		return fmt.Errorf("retention policy must keep the last N tags, delete untagged images or delete tags older than X days")
+	}
+	for _, pattern := range p.ProtectedTagPatterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid protected tag pattern %s: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// IsProtected reports whether the tag matches one of the protected tag patterns
+func (p RetentionPolicy) IsProtected(tag string) bool {
+	for _, pattern := range p.ProtectedTagPatterns {
+		if matched, _ := path.Match(pattern, tag); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// RetentionCandidate is an image the retention policy would delete
+type RetentionCandidate struct {
+	Repository string   `json:"repository"`
+	Tag        string   `json:"tag,omitempty"`
+	Digest     string   `json:"digest"`
+	PushedAt   string   `json:"pushedAt"`
+	SizeBytes  int64    `json:"sizeBytes"`
+	Reason     string   `json:"reason"`
+	InUseBy    []string `json:"inUseBy,omitempty"`
+}
+
+// RetentionResult lists the images a retention policy deleted from a registry
+type RetentionResult struct {
+	RegistryName string               `json:"registryName"`
+	Policy       RetentionPolicy      `json:"policy"`
+	Deleted      []RetentionCandidate `json:"deleted"`
+	// Errors lists the repositories whose tags couldn't be read and the images that couldn't be deleted
+	Errors []string `json:"errors,omitempty"`
+}
+
+// RetentionPreview lists the images a retention policy would delete from a registry
+type RetentionPreview struct {
+	RegistryName string               `json:"registryName"`
+	Policy       RetentionPolicy      `json:"policy"`
+	Candidates   []RetentionCandidate `json:"candidates"`
+	// ReclaimedBytes counts every deleted digest once, a digest that keeps another tag frees nothing
+	ReclaimedBytes int64 `json:"reclaimedBytes"`
+	// Errors lists the repositories whose tags couldn't be read, they are missing from the candidates
+	Errors []string `json:"errors,omitempty"`
+}
+
+// Evaluate returns the images of one repository the policy would delete at the given time
+func (p RetentionPolicy) Evaluate(repository string, tags []ImageTag, now time.Time) []RetentionCandidate {
+	sorted := append([]ImageTag(nil), tags...)
+	SortImageTagsByPushTime(sorted)
+
+	candidates := []RetentionCandidate{}
+	kept := 0
+	for _, tag := range sorted {
+		candidate := RetentionCandidate{
+			Repository: repository,
+			Tag:        tag.Tag,
+			Digest:     tag.Digest,
+			PushedAt:   tag.PushedAt,
+			SizeBytes:  tag.SizeBytes,
+		}
+
+		if tag.Tag == "" {
+			if p.DeleteUntagged {
+				candidate.Reason = "untagged"
+				candidates = append(candidates, candidate)
+			}
+			continue
+		}
+		if p.IsProtected(tag.Tag) {
+			continue
+		}
+
+		// Protected tags don't count towards the kept tags, so they can't push other tags out
+		if p.KeepLastTags > 0 && kept < p.KeepLastTags {
+			kept++
+			continue
+		}
+
+		reason := ""
+		if p.KeepLastTags > 0 {
+			reason = fmt.Sprintf("not among the last %d tags", p.KeepLastTags)
+		}
+		if p.DeleteOlderThanDays > 0 {
+			pushedAt, ok := tag.PushedTime()
+			// A tag with an unknown push time is kept, it can't be proven to be old
+			if !ok || now.Sub(pushedAt) < time.Duration(p.DeleteOlderThanDays)*24*time.Hour {
+				continue
+			}
+			if reason != "" {
+				reason += " and "
+			}
+			reason += fmt.Sprintf("older than %d days", p.DeleteOlderThanDays)
+		}
+		if reason == "" {
+			continue
+		}
+
+		candidate.Reason = reason
+		candidates = append(candidates, candidate)
+	}
+	return candidates
+}
+
+// ReclaimedBytes returns the space the candidates of one repository free: the size of every digest
+// that loses all its tags, counted once. Deleting a tag whose digest keeps another tag only untags the image.
+func ReclaimedBytes(tags []ImageTag, candidates []RetentionCandidate) int64 {
+	deleted := map[string]bool{}
+	for _, candidate := range candidates {
+		deleted[candidate.Tag+"@"+candidate.Digest] = true
+	}
+
+	surviving := map[string]bool{}
+	for _, tag := range tags {
+		if !deleted[tag.Tag+"@"+tag.Digest] {
+			surviving[tag.Digest] = true
+		}
+	}
+
+	var reclaimed int64
+	counted := map[string]bool{}
+	for _, candidate := range candidates {
+		if surviving[candidate.Digest] || counted[candidate.Digest] {
+			continue
+		}
+		counted[candidate.Digest] = true
+		reclaimed += candidate.SizeBytes
+	}
+	return reclaimed
+}
+
+// InUse returns the candidates still used by Container Apps
+func (p RetentionPreview) InUse() []RetentionCandidate {
+	inUse := []RetentionCandidate{}
+	for _, candidate := range p.Candidates {
+		if len(candidate.InUseBy) > 0 {
+			inUse = append(inUse, candidate)
+		}
+	}
+	return inUse
+}
@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetentionPolicyEvaluateKeepsNewestTags(t *testing.T) {
+	// 12:00+03:00 is 09:00Z, so it is the oldest tag although it sorts last as a string
+	tags := []ImageTag{
+		{Tag: "a", Digest: "sha256:a", PushedAt: "2026-06-01T10:00:00Z"},
+		{Tag: "b", Digest: "sha256:b", PushedAt: "2026-06-01T10:00:00.5Z"},
+		{Tag: "c", Digest: "sha256:c", PushedAt: "2026-06-01T12:00:00+03:00"},
+	}
+	policy := RetentionPolicy{KeepLastTags: 1}
+
+	candidates := policy.Evaluate("app", tags, time.Date(2026, 6, 2, 0, 0, 0, 0, time.UTC))
+	deleted := map[string]bool{}
+	for _, candidate := range candidates {
+		deleted[candidate.Tag] = true
+	}
+	if len(candidates) != 2 || deleted["b"] || !deleted["a"] || !deleted["c"] {
+		t.Fatalf("Evaluate() = %+v, want tags a and c deleted and b kept", candidates)
+	}
+}
+
+func TestRetentionPolicyEvaluateOlderThanDays(t *testing.T) {
+	tags := []ImageTag{
+		{Tag: "new", Digest: "sha256:new", PushedAt: "2026-06-01T00:00:00.123+03:00"},
+		{Tag: "old", Digest: "sha256:old", PushedAt: "2026-05-01T00:00:00.123+03:00"},
+		{Tag: "unknown", Digest: "sha256:unknown", PushedAt: ""},
+	}
+	policy := RetentionPolicy{DeleteOlderThanDays: 10}
+
+	candidates := policy.Evaluate("app", tags, time.Date(2026, 6, 2, 0, 0, 0, 0, time.UTC))
+	if len(candidates) != 1 || candidates[0].Tag != "old" {
+		t.Fatalf("Evaluate() = %+v, want only the old tag deleted", candidates)
+	}
+}
+
+func TestRetentionPolicyEvaluateDeleteUntagged(t *testing.T) {
+	tags := []ImageTag{
+		{Tag: "v1", Digest: "sha256:v1", PushedAt: "2026-06-01T10:00:00Z"},
+		{Tag: "", Digest: "sha256:untagged", PushedAt: "2026-05-01T10:00:00Z"},
+	}
+	now := time.Date(2026, 6, 2, 0, 0, 0, 0, time.UTC)
+
+	candidates := RetentionPolicy{DeleteUntagged: true}.Evaluate("app", tags, now)
+	if len(candidates) != 1 || candidates[0].Digest != "sha256:untagged" || candidates[0].Tag != "" || candidates[0].Reason != "untagged" {
+		t.Fatalf("Evaluate() = %+v, want only the untagged image deleted", candidates)
+	}
+
+	// Untagged images are kept unless DeleteUntagged is set, and they don't count towards the kept tags
+	candidates = RetentionPolicy{KeepLastTags: 1}.Evaluate("app", tags, now)
+	if len(candidates) != 0 {
+		t.Fatalf("Evaluate() = %+v, want nothing deleted", candidates)
+	}
+}
+
+func TestRetentionPolicyEvaluateSkipsProtectedTags(t *testing.T) {
+	tags := []ImageTag{
+		{Tag: "latest", Digest: "sha256:latest", PushedAt: "2026-06-01T12:00:00Z"},
+		{Tag: "v2", Digest: "sha256:v2", PushedAt: "2026-06-01T11:00:00Z"},
+		{Tag: "main-2", Digest: "sha256:main2", PushedAt: "2026-06-01T10:00:00Z"},
+		{Tag: "main-1", Digest: "sha256:main1", PushedAt: "2026-06-01T09:00:00Z"},
+	}
+	policy := RetentionPolicy{KeepLastTags: 1, ProtectedTagPatterns: []string{"latest", "v*"}}
+
+	// Protected tags are never deleted and don't push main-2 out of the last tag
+	candidates := policy.Evaluate("app", tags, time.Date(2026, 6, 2, 0, 0, 0, 0, time.UTC))
+	if len(candidates) != 1 || candidates[0].Tag != "main-1" {
+		t.Fatalf("Evaluate() = %+v, want only main-1 deleted", candidates)
+	}
+}
+
+func TestRetentionPolicyIsProtected(t *testing.T) {
+	policy := RetentionPolicy{ProtectedTagPatterns: []string{"v*", "latest", "release-[0-9]*"}}
+	tests := []struct {
+		tag  string
+		want bool
+	}{
+		{tag: "v1.2.3", want: true},
+		{tag: "latest", want: true},
+		{tag: "release-2026", want: true},
+		{tag: "release-candidate", want: false},
+		{tag: "latest-dev", want: false},
+		{tag: "main-1", want: false},
+		{tag: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			if got := policy.IsProtected(tt.tag); got != tt.want {
+				t.Fatalf("IsProtected(%q) = %v, want %v", tt.tag, got, tt.want)
+			}
+		})
+	}
+
+	if (RetentionPolicy{}).IsProtected("latest") {
+		t.Fatalf("IsProtected() = true without protected tag patterns, want false")
+	}
+}
+
+func TestReclaimedBytes(t *testing.T) {
+	tests := []struct {
+		name       string
+		tags       []ImageTag
+		candidates []RetentionCandidate
+		want       int64
+	}{
+		{
+			name: "two tags of one digest count once",
+			tags: []ImageTag{
+				{Tag: "a", Digest: "sha256:x", SizeBytes: 10},
+				{Tag: "b", Digest: "sha256:x", SizeBytes: 10},
+			},
+			candidates: []RetentionCandidate{
+				{Tag: "a", Digest: "sha256:x", SizeBytes: 10},
+				{Tag: "b", Digest: "sha256:x", SizeBytes: 10},
+			},
+			want: 10,
+		},
+		{
+			name: "digest kept by another tag frees nothing",
+			tags: []ImageTag{
+				{Tag: "latest", Digest: "sha256:x", SizeBytes: 10},
+				{Tag: "old", Digest: "sha256:x", SizeBytes: 10},
+			},
+			candidates: []RetentionCandidate{
+				{Tag: "old", Digest: "sha256:x", SizeBytes: 10},
+			},
+			want: 0,
+		},
+		{
+			name: "distinct digests add up",
+			tags: []ImageTag{
+				{Tag: "a", Digest: "sha256:x", SizeBytes: 10},
+				{Tag: "", Digest: "sha256:y", SizeBytes: 5},
+				{Tag: "c", Digest: "sha256:z", SizeBytes: 7},
+			},
+			candidates: []RetentionCandidate{
+				{Tag: "a", Digest: "sha256:x", SizeBytes: 10},
+				{Tag: "", Digest: "sha256:y", SizeBytes: 5},
+			},
+			want: 15,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ReclaimedBytes(tt.tags, tt.candidates); got != tt.want {
+				t.Fatalf("ReclaimedBytes() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
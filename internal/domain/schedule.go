@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Schedule starts and stops the Container Apps matching a selector on cron-like rules,
+// for example to stop dev apps overnight and on weekends
+type Schedule struct {
+	Name      string           `json:"name"`
+	ProjectID string           `json:"projectId"`
+	Selector  ScheduleSelector `json:"selector"`
+	// StartCron and StopCron are five-field cron expressions, at least one of them must be set
+	StartCron string `json:"startCron,omitempty"`
+	StopCron  string `json:"stopCron,omitempty"`
+	// Timezone is an IANA time zone name such as Europe/Moscow, the cron expressions are evaluated in it
+	Timezone string `json:"timezone"`
+}
+
+// ScheduleSelector selects the Container Apps a schedule applies to, with the same criteria as ContainerAppFilter
+type ScheduleSelector struct {
+	NamePattern   string `json:"namePattern,omitempty"`
+	NameRegex     string `json:"nameRegex,omitempty"`
+	ImageContains string `json:"imageContains,omitempty"`
+	Visibility    string `json:"visibility,omitempty"`
+	// Labels is a label selector such as "env=dev,team=billing"
+	Labels string `json:"labels,omitempty"`
+}
+
+// Filter returns a ContainerAppFilter for the selector, limited to Container Apps in the given status
+func (s ScheduleSelector) Filter(status string) (*ContainerAppFilter, error) {
+	return NewContainerAppFilter(s.NamePattern, s.NameRegex, status, s.ImageContains, s.Visibility, s.Labels)
+}
+
+// Location returns the time zone of the schedule
+func (s Schedule) Location() (*time.Location, error) {
+	location, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("schedule %s: invalid timezone %q: %w", s.Name, s.Timezone, err)
+	}
+	return location, nil
+}
+
+// Validate checks that the schedule is complete and its rules can be parsed
+func (s Schedule) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("schedule name must not be empty")
+	}
+	if s.ProjectID == "" {
+		return fmt.Errorf("schedule %s must specify a project ID", s.Name)
+	}
+
+	// Like bulk actions, a schedule must never apply to the whole project by accident
+	filter, err := s.Selector.Filter("")
+	if err != nil {
+		return fmt.Errorf("schedule %s: %w", s.Name, err)
+	}
+	if filter.IsEmpty() {
+		return fmt.Errorf("schedule %s requires a selector, for example a name pattern", s.Name)
+	}
+	// Stop rules run as bulk stops, which need a name or label selector
+	if s.StopCron != "" && !filter.SelectsByNameOrLabels() {
+		return fmt.Errorf("schedule %s with a stop rule requires a name pattern, a name regex or a label selector", s.Name)
+	}
+
+	if s.StartCron == "" && s.StopCron == "" {
+		return fmt.Errorf("schedule %s must specify a start or a stop rule", s.Name)
+	}
+	for _, expression := range []string{s.StartCron, s.StopCron} {
+		if expression == "" {
+			continue
+		}
+		if _, err := ParseCronExpression(expression); err != nil {
+			return fmt.Errorf("schedule %s: %w", s.Name, err)
+		}
+	}
+
+	if _, err := s.Location(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ScheduleRun records one run of a schedule rule
+type ScheduleRun struct {
+	Action string `json:"action"`
+	// ScheduledAt is the minute the rule matched, StartedAt is when the run actually began
+	ScheduledAt time.Time `json:"scheduledAt"`
+	StartedAt   time.Time `json:"startedAt"`
+	Succeeded   []string  `json:"succeeded,omitempty"`
+	Failed      []string  `json:"failed,omitempty"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// ScheduleState is the locally persisted state of a schedule
+type ScheduleState struct {
+	LastStart *ScheduleRun `json:"lastStart,omitempty"`
+	LastStop  *ScheduleRun `json:"lastStop,omitempty"`
+}
+
+// ScheduleStatus describes a schedule together with its last runs and upcoming runs
+type ScheduleStatus struct {
+	Schedule
+	ScheduleState
+	NextStart *time.Time `json:"nextStart,omitempty"`
+	NextStop  *time.Time `json:"nextStop,omitempty"`
+}
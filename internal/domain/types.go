@@ -1,5 +1,11 @@
 package domain
 
+import (
+	"encoding/json"
+	"sort"
+	"time"
+)
+
 // Credentials represents the authentication credentials for Cloud.ru
 type Credentials struct {
 	KeyID     string
@@ -18,75 +24,257 @@ type DockerImage struct {
 
 // ContainerApp represents a Cloud.ru Container App
 type ContainerApp struct {
-	ProjectID     string `json:"projectId"`
-	ID            string `json:"id"`
-	Name          string `json:"name"`
-	Description   string `json:"description"`
-	Status        string `json:"status"`
-	Configuration struct {
-		Ingress struct {
-			PubliclyAccessible bool   `json:"publiclyAccessible"`
-			PublicUri          string `json:"publicUri"`
-			//InternalUri            string        `json:"internalUri"`
-			// AdditionalPortMappings []interface{} `json:"additionalPortMappings"`
-		} `json:"ingress"`
-		AutoDeployments struct {
-			Enabled bool   `json:"enabled"`
-			Pattern string `json:"pattern"`
-		} `json:"autoDeployments"`
-		Privileged bool `json:"privileged"`
-	} `json:"configuration"`
-	Template struct {
-		Timeout     string `json:"timeout"`
-		IdleTimeout string `json:"idleTimeout"`
-		Protocol    string `json:"protocol"`
-		Scaling     struct {
-			MinInstanceCount int `json:"minInstanceCount"`
-			MaxInstanceCount int `json:"maxInstanceCount"`
-			Rule             struct {
-				Type  string `json:"type"`
-				Value struct {
-					Soft int `json:"soft"`
-					Hard int `json:"hard"`
-				} `json:"value"`
-			} `json:"rule"`
-		} `json:"scaling"`
-		Containers []struct {
-			Name      string `json:"name"`
-			Image     string `json:"image"`
-			Resources struct {
-				CPU    string `json:"cpu"`
-				Memory string `json:"memory"`
-			} `json:"resources"`
-			ContainerPort int `json:"containerPort"`
-			Env           []struct {
-				Name  string `json:"name"`
-				Value string `json:"value"`
-				Type  string `json:"type,omitempty"`
-			} `json:"env"`
-			Command      []interface{} `json:"command"`
-			Args         []interface{} `json:"args"`
-			VolumeMounts []struct {
-				Name      string `json:"name"`
-				MountPath string `json:"mountPath"`
-				ReadOnly  bool   `json:"readOnly"`
-			} `json:"volumeMounts"`
-		} `json:"containers"`
-		InitContainers []interface{} `json:"initContainers"`
-		Volumes        []struct {
-			Name             string `json:"name"`
-			Type             string `json:"type"`
-			VolumeAttributes struct {
-				BucketName string `json:"bucketName"`
-				TenantId   string `json:"tenantId"`
-				Region     string `json:"region"`
-				ReadOnly   string `json:"readOnly"`
-				Entrypoint string `json:"entrypoint"`
-			} `json:"volumeAttributes"`
-		} `json:"volumes"`
-	} `json:"template"`
+	ProjectID     string                    `json:"projectId"`
+	ID            string                    `json:"id"`
+	Name          string                    `json:"name"`
+	Description   string                    `json:"description"`
+	Labels        map[string]string         `json:"labels,omitempty"`
+	Status        string                    `json:"status"`
+	CreatedAt     string                    `json:"createdAt,omitempty"`
+	UpdatedAt     string                    `json:"updatedAt,omitempty"`
+	Configuration ContainerAppConfiguration `json:"configuration"`
+	Template      ContainerAppTemplate      `json:"template"`
+	// OperationID identifies the asynchronous platform operation started by the change that returned this Container App
+	OperationID string `json:"operationId,omitempty"`
+}
+
+// ContainerAppConfiguration represents the settings of a Container App that apply to all of its revisions
+type ContainerAppConfiguration struct {
+	Ingress         Ingress         `json:"ingress"`
+	AutoDeployments AutoDeployments `json:"autoDeployments"`
+	Privileged      bool            `json:"privileged"`
+}
+
+// ContainerAppTemplate represents what runs in a Container App: its containers, volumes, scaling and request handling
+type ContainerAppTemplate struct {
+	Timeout        string      `json:"timeout"`
+	IdleTimeout    string      `json:"idleTimeout"`
+	Protocol       string      `json:"protocol"`
+	Scaling        Scaling     `json:"scaling"`
+	Containers     []Container `json:"containers"`
+	InitContainers []Container `json:"initContainers"`
+	Volumes        []Volume    `json:"volumes"`
+}
+
+// Scaling represents how many instances of a Container App run and when new ones are added
+type Scaling struct {
+	MinInstanceCount int         `json:"minInstanceCount"`
+	MaxInstanceCount int         `json:"maxInstanceCount"`
+	Rule             ScalingRule `json:"rule"`
+}
+
+// ScalingRule represents the metric that triggers scaling of a Container App
+type ScalingRule struct {
+	Type  string           `json:"type"`
+	Value ScalingRuleValue `json:"value"`
+}
+
+// ScalingRuleValue represents the soft and hard limits of a scaling metric per instance
+type ScalingRuleValue struct {
+	Soft int `json:"soft"`
+	Hard int `json:"hard"`
+}
+
+// ContainerAppSummary is a compact view of a Container App for listings
+type ContainerAppSummary struct {
+	Name         string `json:"name"`
+	Status       string `json:"status"`
+	Image        string `json:"image"`
+	URI          string `json:"uri"`
+	MinInstances int    `json:"minInstances"`
+	MaxInstances int    `json:"maxInstances"`
+	// Labels tell who owns the Container App in a shared project
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// Summary returns a compact view of the Container App
+func (c ContainerApp) Summary() ContainerAppSummary {
+	summary := ContainerAppSummary{
+		Name:         c.Name,
+		Status:       c.Status,
+		URI:          c.Configuration.Ingress.PublicUri,
+		MinInstances: c.Template.Scaling.MinInstanceCount,
+		MaxInstances: c.Template.Scaling.MaxInstanceCount,
+		Labels:       c.Labels,
+	}
+	if summary.URI == "" {
+		summary.URI = c.Configuration.Ingress.InternalUri
+	}
+	if len(c.Template.Containers) > 0 {
+		summary.Image = c.Template.Containers[0].Image
+	}
+	return summary
+}
+
+// Images returns the images of all containers, sidecars and init containers of the Container App
+func (c ContainerApp) Images() []string {
+	images := []string{}
+	for _, containers := range [][]Container{c.Template.Containers, c.Template.InitContainers} {
+		for _, container := range containers {
+			images = append(images, container.Image)
+		}
+	}
+	return images
+}
+
+// Statuses of a Container App reported by Cloud.ru API
+const (
+	ContainerAppStatusRunning = "RUNNING"
+	ContainerAppStatusStopped = "STOPPED"
+	ContainerAppStatusFailed  = "FAILED"
+)
+
+// RestartResult describes how long each phase of a Container App restart took
+type RestartResult struct {
+	StopDuration  time.Duration
+	StartDuration time.Duration
+}
+
+// Modes of creating a Container App that already exists
+const (
+	IfExistsError  = "error"
+	IfExistsSkip   = "skip"
+	IfExistsUpdate = "update"
+)
+
+// Outcomes of creating a Container App
+const (
+	CreateActionCreated = "created"
+	CreateActionSkipped = "skipped"
+	CreateActionUpdated = "updated"
+)
+
+// CreateResult describes what was done to converge a Container App to the requested state
+type CreateResult struct {
+	Action       string
+	ContainerApp *ContainerApp
+}
+
+// CloneOptions describes how a Container App copy differs from its source
+type CloneOptions struct {
+	TargetProjectID string
+	TargetName      string
+	Image           string
+	Env             []EnvVar
+	CopySecrets     bool
+}
+
+// CloneResult describes a Container App created as a copy of another one
+type CloneResult struct {
+	ContainerApp *ContainerApp
+	// SkippedSecretEnv lists secret environment variables that were not carried over to the copy
+	SkippedSecretEnv []string
+}
+
+// Actions that can be applied to several Container Apps at once
+const (
+	BulkActionStart  = "start"
+	BulkActionStop   = "stop"
+	BulkActionDelete = "delete"
+)
+
+// BulkResult describes the outcome of an action applied to every Container App matching a selector
+type BulkResult struct {
+	Action string
+	DryRun bool
+	Items  []BulkItemResult
+}
+
+// BulkItemResult describes the outcome of a bulk action for a single Container App
+type BulkItemResult struct {
+	Name        string
+	Status      string
+	OperationID string
+	Error       string
+}
+
+// Failed returns the number of Container Apps the bulk action failed for
+func (r BulkResult) Failed() int {
+	failed := 0
+	for _, item := range r.Items {
+		if item.Error != "" {
+			failed++
+		}
+	}
+	return failed
+}
+
+// Destructive actions guarded by the protection subsystem
+const (
+	DestructiveActionDelete       = "delete"
+	DestructiveActionStop         = "stop"
+	DestructiveActionDetachDomain = "detach domain"
+	// DestructiveActionRestart stops and starts a Container App, it stays stopped if the start fails
+	DestructiveActionRestart = "restart"
+	// DestructiveActionDeleteRegistry deletes a Docker Registry with all its images
+	DestructiveActionDeleteRegistry = "delete registry"
+	// DestructiveActionDeleteImage deletes an image tag or an image digest with all its tags
+	DestructiveActionDeleteImage = "delete image"
+	// DestructiveActionApplyRetention deletes an image selected by a retention policy
+	DestructiveActionApplyRetention = "apply retention policy"
+)
+
+// AuditRecord is an entry of the destructive actions log. It keeps the last known
+// specification of the Container App, so the Container App can be recreated
+type AuditRecord struct {
+	Time             time.Time     `json:"time"`
+	Action           string        `json:"action"`
+	ProjectID        string        `json:"projectId"`
+	ContainerAppName string        `json:"containerAppName"`
+	LastKnownSpec    *ContainerApp `json:"lastKnownSpec,omitempty"`
+	CustomDomain     string        `json:"customDomain,omitempty"`
+	RegistryName     string        `json:"registryName,omitempty"`
+	Image            string        `json:"image,omitempty"`
+	OperationID      string        `json:"operationId,omitempty"`
+	// Force is set when the action was performed despite a failed safety check, e.g. an image still in use
+	Force bool   `json:"force,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// Statuses of an asynchronous platform operation
+const (
+	OperationStatusInProgress = "IN_PROGRESS"
+	OperationStatusDone       = "DONE"
+	OperationStatusFailed     = "FAILED"
+)
+
+// Operation is an asynchronous platform operation, such as creating, deleting, starting or stopping a Container App
+type Operation struct {
+	ID           string          `json:"id"`
+	Description  string          `json:"description,omitempty"`
+	ResourceID   string          `json:"resourceId,omitempty"`
+	ResourceName string          `json:"resourceName,omitempty"`
+	CreatedAt    string          `json:"createdAt,omitempty"`
+	ModifiedAt   string          `json:"modifiedAt,omitempty"`
+	Done         bool            `json:"done"`
+	Metadata     json.RawMessage `json:"metadata,omitempty"`
+	Error        *OperationError `json:"error,omitempty"`
+}
+
+// OperationError describes why an operation failed
+type OperationError struct {
+	Message string          `json:"message"`
+	Details json.RawMessage `json:"details,omitempty"`
+}
+
+// Status returns whether the operation is still in progress, has completed or has failed
+func (o Operation) Status() string {
+	switch {
+	case o.Error != nil:
+		return OperationStatusFailed
+	case o.Done:
+		return OperationStatusDone
+	default:
+		return OperationStatusInProgress
+	}
 }
 
+// Supported formats of exported Container App manifests
+const (
+	ManifestFormatYAML = "yaml"
+	ManifestFormatJSON = "json"
+)
+
 // DockerRegistry represents a Cloud.ru Docker Registry
 type DockerRegistry struct {
 	ID                       string `json:"id"`
@@ -100,3 +288,145 @@ type DockerRegistry struct {
 	IsPublic                 bool   `json:"isPublic"`
 	QuarantineMode           string `json:"quarantineMode"`
 }
+
+// Repository is a repository of images in a Docker Registry
+type Repository struct {
+	Name      string `json:"name"`
+	TagsCount int    `json:"tagsCount"`
+	SizeBytes int64  `json:"sizeBytes"`
+	CreatedAt string `json:"createdAt,omitempty"`
+	UpdatedAt string `json:"updatedAt,omitempty"`
+}
+
+// ImageTag is a tagged image in a repository. Image is the full reference to use in a Container App
+type ImageTag struct {
+	Tag       string          `json:"tag"`
+	Digest    string          `json:"digest"`
+	SizeBytes int64           `json:"sizeBytes"`
+	PushedAt  string          `json:"pushedAt"`
+	Platforms []ImagePlatform `json:"platforms,omitempty"`
+	Image     string          `json:"image"`
+}
+
+// PushedTime returns the parsed push time of the tag, ok is false if it is missing or invalid
+func (t ImageTag) PushedTime() (time.Time, bool) {
+	pushedAt, err := time.Parse(time.RFC3339Nano, t.PushedAt)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return pushedAt, true
+}
+
+// SortImageTagsByPushTime sorts the tags with the most recently pushed first and the ones with an unknown push time last.
+// Push times are compared as times, since their offsets and fractional seconds may differ.
+func SortImageTagsByPushTime(tags []ImageTag) {
+	sort.SliceStable(tags, func(i, j int) bool {
+		iPushedAt, iOK := tags[i].PushedTime()
+		jPushedAt, jOK := tags[j].PushedTime()
+		if iOK != jOK {
+			return iOK
+		}
+		return iPushedAt.After(jPushedAt)
+	})
+}
+
+// ImagePlatform is an operating system and CPU architecture an image is built for
+type ImagePlatform struct {
+	OS           string `json:"os"`
+	Architecture string `json:"architecture"`
+	Variant      string `json:"variant,omitempty"`
+}
+
+// DeleteImageResult describes a deleted image tag or digest. Deleting a digest removes all its tags
+type DeleteImageResult struct {
+	Image       string   `json:"image"`
+	Digest      string   `json:"digest,omitempty"`
+	DeletedTags []string `json:"deletedTags"`
+	OperationID string   `json:"operationId,omitempty"`
+}
+
+// DeleteRegistryResult describes a deleted Docker Registry. ReferencingContainerApps lists the running
+// Container Apps whose images were stored in the registry, they are only deleted with force
+type DeleteRegistryResult struct {
+	RegistryName             string   `json:"registryName"`
+	ReferencingContainerApps []string `json:"referencingContainerApps,omitempty"`
+	OperationID              string   `json:"operationId,omitempty"`
+}
+
+// Ingress represents the network exposure settings of a Container App
+type Ingress struct {
+	PubliclyAccessible     bool          `json:"publiclyAccessible"`
+	PublicUri              string        `json:"publicUri,omitempty"`
+	InternalUri            string        `json:"internalUri,omitempty"`
+	AdditionalPortMappings []PortMapping `json:"additionalPortMappings"`
+	// ContainerName is the container that receives ingress traffic when the Container App has several containers
+	ContainerName string `json:"containerName,omitempty"`
+}
+
+// PortMapping represents an additional port exposed by a Container App besides the main container port
+type PortMapping struct {
+	Port          int    `json:"port"`
+	ContainerPort int    `json:"containerPort"`
+	Protocol      string `json:"protocol,omitempty"`
+}
+
+// AutoDeployments represents the settings for redeploying a Container App when a new image tag
+// matching the pattern is pushed to the registry
+type AutoDeployments struct {
+	Enabled bool   `json:"enabled"`
+	Pattern string `json:"pattern"`
+}
+
+// Container represents a container of a Container App. Init containers use the same model
+type Container struct {
+	Name          string        `json:"name"`
+	Image         string        `json:"image"`
+	Resources     Resources     `json:"resources"`
+	ContainerPort int           `json:"containerPort"`
+	Env           []EnvVar      `json:"env"`
+	Command       []string      `json:"command"`
+	Args          []string      `json:"args"`
+	VolumeMounts  []VolumeMount `json:"volumeMounts"`
+}
+
+// EnvVarTypeSecret is the type of environment variables whose value is a secret
+const EnvVarTypeSecret = "secret"
+
+// Resources represents the CPU and memory allocated to a container
+type Resources struct {
+	CPU    string `json:"cpu"`
+	Memory string `json:"memory"`
+}
+
+// EnvVar represents an environment variable of a container
+type EnvVar struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	Type  string `json:"type,omitempty"`
+}
+
+// VolumeMount represents a volume mounted into a container
+type VolumeMount struct {
+	Name      string `json:"name"`
+	MountPath string `json:"mountPath"`
+	ReadOnly  bool   `json:"readOnly"`
+}
+
+// BucketVolumeType is the volume type of Object Storage (S3) buckets
+const BucketVolumeType = "s3"
+
+// Volume represents a volume of a Container App backed by an Object Storage bucket
+type Volume struct {
+	Name             string           `json:"name"`
+	Type             string           `json:"type"`
+	VolumeAttributes VolumeAttributes `json:"volumeAttributes"`
+}
+
+// VolumeAttributes represents the Object Storage bucket behind a volume
+type VolumeAttributes struct {
+	BucketName string `json:"bucketName"`
+	TenantId   string `json:"tenantId"`
+	Region     string `json:"region"`
+	ReadOnly   string `json:"readOnly"`
+	Entrypoint string `json:"entrypoint"`
+}
@@ -0,0 +1,211 @@
+package domain
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestSortImageTagsByPushTime(t *testing.T) {
+	tags := []ImageTag{
+		{Tag: "unknown", PushedAt: ""},
+		{Tag: "moscow", PushedAt: "2026-06-01T12:00:00+03:00"},
+		{Tag: "utc", PushedAt: "2026-06-01T10:00:00Z"},
+		{Tag: "fraction", PushedAt: "2026-06-01T10:00:00.5Z"},
+	}
+	SortImageTagsByPushTime(tags)
+
+	want := []string{"fraction", "utc", "moscow", "unknown"}
+	for i, tag := range tags {
+		if tag.Tag != want[i] {
+			t.Fatalf("tag %d = %s, want %s (order %v)", i, tag.Tag, want[i], tags)
+		}
+	}
+}
+
+// containerAppJSON is a v2 Container App as returned by the API, with every field of the model set
+const containerAppJSON = `{
+  "projectId": "7a2f3c1e-0000-4000-8000-000000000001",
+  "id": "0d6a1c2b-0000-4000-8000-000000000002",
+  "name": "billing-backend",
+  "description": "Billing backend",
+  "labels": {"team": "billing", "env": "prod"},
+  "status": "RUNNING",
+  "createdAt": "2026-01-15T10:00:00Z",
+  "updatedAt": "2026-02-01T12:30:00Z",
+  "configuration": {
+    "ingress": {
+      "publiclyAccessible": false,
+      "publicUri": "https://billing-backend.containers.cloud.ru",
+      "internalUri": "http://billing-backend.internal",
+      "additionalPortMappings": [
+        {"port": 9090, "containerPort": 9090, "protocol": "grpc"}
+      ],
+      "containerName": "billing-backend"
+    },
+    "autoDeployments": {"enabled": true, "pattern": "v*"},
+    "privileged": false
+  },
+  "template": {
+    "timeout": "30s",
+    "idleTimeout": "120s",
+    "protocol": "http2",
+    "scaling": {
+      "minInstanceCount": 1,
+      "maxInstanceCount": 5,
+      "rule": {"type": "concurrency", "value": {"soft": 10, "hard": 20}}
+    },
+    "containers": [
+      {
+        "name": "billing-backend",
+        "image": "billing.cr.cloud.ru/backend:v1.2.3",
+        "resources": {"cpu": "0.5", "memory": "1Gi"},
+        "containerPort": 8080,
+        "env": [
+          {"name": "CONTAINERAPP_NAME", "value": "billing-backend"},
+          {"name": "DB_PASSWORD", "value": "secret-ref", "type": "secret"}
+        ],
+        "command": ["/app/server"],
+        "args": ["--port", "8080"],
+        "volumeMounts": [{"name": "assets", "mountPath": "/data", "readOnly": true}]
+      },
+      {
+        "name": "metrics-proxy",
+        "image": "billing.cr.cloud.ru/metrics-proxy@sha256:a1a1e0a11299668c5f05a299f74b3943236ca3390a6fda64e98cc2498064c266",
+        "resources": {"cpu": "0.1", "memory": "128Mi"},
+        "containerPort": 9100,
+        "env": [],
+        "command": [],
+        "args": [],
+        "volumeMounts": []
+      }
+    ],
+    "initContainers": [
+      {
+        "name": "migrate",
+        "image": "billing.cr.cloud.ru/backend:v1.2.3",
+        "resources": {"cpu": "0.5", "memory": "512Mi"},
+        "containerPort": 0,
+        "env": [{"name": "MODE", "value": "migrate"}],
+        "command": ["/app/migrate"],
+        "args": [],
+        "volumeMounts": []
+      }
+    ],
+    "volumes": [
+      {
+        "name": "assets",
+        "type": "s3",
+        "volumeAttributes": {
+          "bucketName": "billing-assets",
+          "tenantId": "tenant-1",
+          "region": "ru-central-1",
+          "readOnly": "true",
+          "entrypoint": "https://s3.cloud.ru"
+        }
+      }
+    ]
+  },
+  "operationId": "op-0000-0001"
+}`
+
+// TestContainerAppAPIRoundTrip decodes an API response into the model and encodes it again, nothing may be lost or added
+func TestContainerAppAPIRoundTrip(t *testing.T) {
+	var containerApp ContainerApp
+	if err := json.Unmarshal([]byte(containerAppJSON), &containerApp); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	encoded, err := json.Marshal(containerApp)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var want, got interface{}
+	if err := json.Unmarshal([]byte(containerAppJSON), &want); err != nil {
+		t.Fatalf("Unmarshal() of the fixture error = %v", err)
+	}
+	if err := json.Unmarshal(encoded, &got); err != nil {
+		t.Fatalf("Unmarshal() of the encoded container app error = %v", err)
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("round trip mismatch:\nwant: %s\ngot:  %s", containerAppJSON, encoded)
+	}
+}
+
+// TestContainerAppModelRoundTrip encodes a ContainerApp built from the named types and decodes it, the value must not change
+func TestContainerAppModelRoundTrip(t *testing.T) {
+	sidecar := Container{
+		Name:          "metrics-proxy",
+		Image:         "billing.cr.cloud.ru/metrics-proxy:v2",
+		Resources:     Resources{CPU: "0.1", Memory: "128Mi"},
+		ContainerPort: 9100,
+		Env:           []EnvVar{{Name: "TARGET", Value: "localhost:8080"}},
+		Command:       []string{"/proxy"},
+		Args:          []string{"--listen", ":9100"},
+		VolumeMounts:  []VolumeMount{},
+	}
+	volume := Volume{
+		Name: "assets",
+		Type: BucketVolumeType,
+		VolumeAttributes: VolumeAttributes{
+			BucketName: "billing-assets",
+			TenantId:   "tenant-1",
+			Region:     "ru-central-1",
+			ReadOnly:   "true",
+			Entrypoint: "https://s3.cloud.ru",
+		},
+	}
+	original := ContainerApp{
+		ProjectID:   "7a2f3c1e-0000-4000-8000-000000000001",
+		Name:        "billing-backend",
+		Description: "Billing backend",
+		Labels:      map[string]string{"team": "billing", "git-sha": "3f2a1bc"},
+		Configuration: ContainerAppConfiguration{
+			Ingress: Ingress{
+				PubliclyAccessible:     true,
+				AdditionalPortMappings: []PortMapping{{Port: 9090, ContainerPort: 9090}},
+				ContainerName:          "billing-backend",
+			},
+			AutoDeployments: AutoDeployments{Enabled: true, Pattern: "main-*"},
+		},
+		Template: ContainerAppTemplate{
+			Timeout:  "60s",
+			Protocol: ProtocolHTTP1,
+			Scaling: Scaling{
+				MinInstanceCount: 0,
+				MaxInstanceCount: 3,
+				Rule: ScalingRule{
+					Type:  "concurrency",
+					Value: ScalingRuleValue{Soft: 5, Hard: 10},
+				},
+			},
+			Containers: []Container{
+				{
+					Name:          "billing-backend",
+					Image:         "billing.cr.cloud.ru/backend:v1.2.3",
+					Resources:     Resources{CPU: "0.5", Memory: "1Gi"},
+					ContainerPort: 8080,
+					Env:           []EnvVar{{Name: "DB_PASSWORD", Value: "secret-ref", Type: EnvVarTypeSecret}},
+					Command:       []string{},
+					Args:          []string{},
+					VolumeMounts:  []VolumeMount{{Name: "assets", MountPath: "/data", ReadOnly: true}},
+				},
+				sidecar,
+			},
+			InitContainers: []Container{},
+			Volumes:        []Volume{volume},
+		},
+	}
+
+	encoded, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var decoded ContainerApp
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(original, decoded) {
+		t.Fatalf("round trip mismatch:\nwant: %+v\ngot:  %+v", original, decoded)
+	}
+}
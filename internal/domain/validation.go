@@ -0,0 +1,178 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Length limits of Cloud.ru resource names. Both names end up in DNS names, so they follow the DNS label rules.
+const (
+	MaxContainerAppNameLength = 63
+	MinRegistryNameLength     = 3
+	MaxRegistryNameLength     = 63
+	MaxImageTagLength         = 128
+	MaxDescriptionLength      = 255
+	MaxLabelKeyLength         = 63
+	MaxLabelValueLength       = 63
+)
+
+var (
+	// resourceNameRegexp matches lowercase latin letters, digits and hyphens, starting with a letter and not ending with a hyphen
+	resourceNameRegexp = regexp.MustCompile(`^[a-z]([a-z0-9-]*[a-z0-9])?$`)
+
+	// The image reference grammar follows the Docker distribution reference format: [registry/]repository[:tag][@digest]
+	registryHostRegexp   = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?)*(:[0-9]+)?$`)
+	repositoryPathRegexp = regexp.MustCompile(`^[a-z0-9]+((\.|_|__|-+)[a-z0-9]+)*(/[a-z0-9]+((\.|_|__|-+)[a-z0-9]+)*)*$`)
+	imageTagRegexp       = regexp.MustCompile(`^[a-zA-Z0-9_][a-zA-Z0-9_.-]{0,127}$`)
+	imageDigestRegexp    = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9]*([-_+.][a-zA-Z][a-zA-Z0-9]*)*:[0-9a-fA-F]{32,}$`)
+
+	envVarNameRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+	labelKeyRegexp   = regexp.MustCompile(`^[a-z0-9]([a-z0-9_.-]*[a-z0-9])?$`)
+	labelValueRegexp = regexp.MustCompile(`^[a-zA-Z0-9_.-]*$`)
+)
+
+// ValidateContainerAppName checks a Container App name against Cloud.ru naming rules
+func ValidateContainerAppName(name string) error {
+	if name == "" {
+		return fmt.Errorf("container app name must not be empty")
+	}
+	if len(name) > MaxContainerAppNameLength {
+		return fmt.Errorf("container app name %q is %d characters long, the maximum is %d", name, len(name), MaxContainerAppNameLength)
+	}
+	if !resourceNameRegexp.MatchString(name) {
+		return fmt.Errorf("container app name %q must contain only lowercase latin letters, digits and hyphens, start with a letter and not end with a hyphen", name)
+	}
+	return nil
+}
+
+// ValidateRegistryName checks a Docker Registry name against Cloud.ru naming rules
+func ValidateRegistryName(name string) error {
+	if name == "" {
+		return fmt.Errorf("registry name must not be empty")
+	}
+	if len(name) < MinRegistryNameLength || len(name) > MaxRegistryNameLength {
+		return fmt.Errorf("registry name %q must be from %d to %d characters long", name, MinRegistryNameLength, MaxRegistryNameLength)
+	}
+	if !resourceNameRegexp.MatchString(name) {
+		return fmt.Errorf("registry name %q must contain only lowercase latin letters, digits and hyphens, start with a letter and not end with a hyphen", name)
+	}
+	return nil
+}
+
+// ValidateRepositoryName checks a repository name of a Docker Registry, e.g. team/my-app
+func ValidateRepositoryName(name string) error {
+	if name == "" {
+		return fmt.Errorf("repository name must not be empty")
+	}
+	if !repositoryPathRegexp.MatchString(name) {
+		return fmt.Errorf("repository name %q must contain only lowercase latin letters, digits and separators (., _, __, -), with components split by /", name)
+	}
+	return nil
+}
+
+// ValidateImageTag checks a Docker image tag
+func ValidateImageTag(tag string) error {
+	if !imageTagRegexp.MatchString(tag) {
+		return fmt.Errorf("image tag %q must be up to %d latin letters, digits, underscores, periods and hyphens, and must not start with a period or hyphen", tag, MaxImageTagLength)
+	}
+	return nil
+}
+
+// ValidatePort checks that a port is within the TCP range
+func ValidatePort(name string, port int) error {
+	if port < 1 || port > 65535 {
+		return fmt.Errorf("%s %d must be between 1 and 65535", name, port)
+	}
+	return nil
+}
+
+// ValidateImageReference checks the syntax of an image reference: [registry/]repository[:tag][@digest]
+func ValidateImageReference(image string) error {
+	if image == "" {
+		return fmt.Errorf("image must not be empty")
+	}
+	if strings.TrimSpace(image) != image {
+		return fmt.Errorf("image %q must not contain surrounding whitespace", image)
+	}
+
+	name := image
+	if i := strings.Index(name, "@"); i != -1 {
+		digest := name[i+1:]
+		name = name[:i]
+		if !imageDigestRegexp.MatchString(digest) {
+			return fmt.Errorf("image %q has an invalid digest %q, expected algorithm:hex such as sha256:<64 hex characters>", image, digest)
+		}
+	}
+
+	// A colon after the last slash separates the tag, a colon before it belongs to the registry port
+	if i := strings.LastIndex(name, ":"); i != -1 && i > strings.LastIndex(name, "/") {
+		tag := name[i+1:]
+		name = name[:i]
+		if err := ValidateImageTag(tag); err != nil {
+			return fmt.Errorf("image %q: %w", image, err)
+		}
+	}
+
+	repository := name
+	if i := strings.Index(name, "/"); i != -1 {
+		host := name[:i]
+		// The first component is a registry only if it looks like a host, otherwise it is a part of the repository path
+		if strings.ContainsAny(host, ".:") || host == "localhost" {
+			if !registryHostRegexp.MatchString(host) {
+				return fmt.Errorf("image %q has an invalid registry %q", image, host)
+			}
+			repository = name[i+1:]
+		}
+	}
+	if !repositoryPathRegexp.MatchString(repository) {
+		return fmt.Errorf("image %q has an invalid repository %q, it must contain only lowercase latin letters, digits and separators (., _, __, -)", image, repository)
+	}
+
+	return nil
+}
+
+// ValidateEnvVars checks that environment variable names are valid and unique
+func ValidateEnvVars(env []EnvVar) error {
+	names := map[string]bool{}
+	for _, envVar := range env {
+		if !envVarNameRegexp.MatchString(envVar.Name) {
+			return fmt.Errorf("environment variable name %q must contain only latin letters, digits and underscores and must not start with a digit", envVar.Name)
+		}
+		if names[envVar.Name] {
+			return fmt.Errorf("environment variable %s is set more than once", envVar.Name)
+		}
+		names[envVar.Name] = true
+	}
+	return nil
+}
+
+// ValidateContainer checks the image reference, the port and the environment variables of a container
+func ValidateContainer(container Container) error {
+	if err := ValidateImageReference(container.Image); err != nil {
+		return fmt.Errorf("container %s: %w", container.Name, err)
+	}
+	if container.ContainerPort != 0 {
+		if err := ValidatePort("container port", container.ContainerPort); err != nil {
+			return fmt.Errorf("container %s: %w", container.Name, err)
+		}
+	}
+	if err := ValidateEnvVars(container.Env); err != nil {
+		return fmt.Errorf("container %s: %w", container.Name, err)
+	}
+	return nil
+}
+
+// ValidateLabels checks label keys and values, e.g. team=billing or git-sha=3f2a1bc. An empty value is allowed, it removes the label
+func ValidateLabels(labels map[string]string) error {
+	for key, value := range labels {
+		if len(key) > MaxLabelKeyLength || !labelKeyRegexp.MatchString(key) {
+			return fmt.Errorf("label key %q must be up to %d lowercase latin letters, digits, underscores, periods and hyphens, starting and ending with a letter or digit", key, MaxLabelKeyLength)
+		}
+		if len(value) > MaxLabelValueLength || !labelValueRegexp.MatchString(value) {
+			return fmt.Errorf("label %s value %q must be up to %d latin letters, digits, underscores, periods and hyphens", key, value, MaxLabelValueLength)
+		}
+	}
+	return nil
+}
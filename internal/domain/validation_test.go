@@ -0,0 +1,205 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateContainerAppName(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "valid", value: "my-app-1"},
+		{name: "single letter", value: "a"},
+		{name: "maximum length", value: "a" + strings.Repeat("b", MaxContainerAppNameLength-1)},
+		{name: "empty", value: "", wantErr: true},
+		{name: "too long", value: "a" + strings.Repeat("b", MaxContainerAppNameLength), wantErr: true},
+		{name: "uppercase", value: "My-app", wantErr: true},
+		{name: "starts with digit", value: "1app", wantErr: true},
+		{name: "ends with hyphen", value: "app-", wantErr: true},
+		{name: "underscore", value: "my_app", wantErr: true},
+		{name: "slash", value: "my/app", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateContainerAppName(tt.value); (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateContainerAppName(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateRegistryName(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "valid", value: "my-registry"},
+		{name: "minimum length", value: "abc"},
+		{name: "maximum length", value: "a" + strings.Repeat("b", MaxRegistryNameLength-1)},
+		{name: "empty", value: "", wantErr: true},
+		{name: "too short", value: "ab", wantErr: true},
+		{name: "too long", value: "a" + strings.Repeat("b", MaxRegistryNameLength), wantErr: true},
+		{name: "uppercase", value: "Registry", wantErr: true},
+		{name: "starts with hyphen", value: "-registry", wantErr: true},
+		{name: "period", value: "my.registry", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateRegistryName(tt.value); (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateRegistryName(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateRepositoryName(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "single component", value: "my-app"},
+		{name: "nested components", value: "team/my_app/api"},
+		{name: "double underscore", value: "my__app"},
+		{name: "empty", value: "", wantErr: true},
+		{name: "uppercase", value: "MyApp", wantErr: true},
+		{name: "leading slash", value: "/my-app", wantErr: true},
+		{name: "trailing separator", value: "my-app-", wantErr: true},
+		{name: "tag", value: "my-app:latest", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateRepositoryName(tt.value); (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateRepositoryName(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateImageTag(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "latest", value: "latest"},
+		{name: "version", value: "v1.2.3-rc_1"},
+		{name: "maximum length", value: strings.Repeat("a", MaxImageTagLength)},
+		{name: "empty", value: "", wantErr: true},
+		{name: "too long", value: strings.Repeat("a", MaxImageTagLength+1), wantErr: true},
+		{name: "starts with period", value: ".v1", wantErr: true},
+		{name: "starts with hyphen", value: "-v1", wantErr: true},
+		{name: "slash", value: "v1/2", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateImageTag(tt.value); (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateImageTag(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidatePort(t *testing.T) {
+	tests := []struct {
+		name    string
+		port    int
+		wantErr bool
+	}{
+		{name: "minimum", port: 1},
+		{name: "http", port: 8080},
+		{name: "maximum", port: 65535},
+		{name: "zero", port: 0, wantErr: true},
+		{name: "negative", port: -1, wantErr: true},
+		{name: "too large", port: 65536, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidatePort("port", tt.port); (err != nil) != tt.wantErr {
+				t.Fatalf("ValidatePort(%d) error = %v, wantErr %v", tt.port, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateImageReference(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("a", 64)
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "repository only", value: "nginx"},
+		{name: "repository and tag", value: "nginx:1.25"},
+		{name: "nested repository", value: "library/nginx:latest"},
+		{name: "registry host", value: "my-registry.cr.cloud.ru/team/app:v1"},
+		{name: "registry with port", value: "localhost:5000/app:v1"},
+		{name: "registry with port without tag", value: "registry.local:5000/app"},
+		{name: "digest", value: "nginx@" + digest},
+		{name: "tag and digest", value: "nginx:1.25@" + digest},
+		{name: "empty", value: "", wantErr: true},
+		{name: "surrounding whitespace", value: " nginx", wantErr: true},
+		{name: "uppercase repository", value: "Nginx", wantErr: true},
+		{name: "invalid tag", value: "nginx:-bad", wantErr: true},
+		{name: "empty tag", value: "nginx:", wantErr: true},
+		{name: "short digest", value: "nginx@sha256:abc", wantErr: true},
+		{name: "invalid registry host", value: "my_registry.io/app", wantErr: true},
+		{name: "double slash", value: "team//app", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateImageReference(tt.value); (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateImageReference(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateEnvVars(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     []EnvVar
+		wantErr bool
+	}{
+		{name: "none"},
+		{name: "valid", env: []EnvVar{{Name: "PORT", Value: "8080"}, {Name: "_DEBUG", Value: ""}, {Name: "db_url1"}}},
+		{name: "empty name", env: []EnvVar{{Name: ""}}, wantErr: true},
+		{name: "starts with digit", env: []EnvVar{{Name: "1PORT"}}, wantErr: true},
+		{name: "hyphen", env: []EnvVar{{Name: "MY-VAR"}}, wantErr: true},
+		{name: "duplicate", env: []EnvVar{{Name: "PORT", Value: "1"}, {Name: "PORT", Value: "2"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateEnvVars(tt.env); (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateEnvVars() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		labels  map[string]string
+		wantErr bool
+	}{
+		{name: "valid", labels: map[string]string{"team": "billing", "git-sha": "3f2a1bc", "app.kubernetes.io_name": "API"}},
+		{name: "empty value removes the label", labels: map[string]string{"team": ""}},
+		{name: "uppercase key", labels: map[string]string{"Team": "billing"}, wantErr: true},
+		{name: "key ends with hyphen", labels: map[string]string{"team-": "billing"}, wantErr: true},
+		{name: "key too long", labels: map[string]string{strings.Repeat("a", MaxLabelKeyLength+1): "x"}, wantErr: true},
+		{name: "value with space", labels: map[string]string{"team": "bill ing"}, wantErr: true},
+		{name: "value too long", labels: map[string]string{"team": strings.Repeat("a", MaxLabelValueLength+1)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateLabels(tt.labels); (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateLabels() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
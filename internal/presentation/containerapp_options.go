@@ -0,0 +1,280 @@
+package presentation
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// getContainerAppOptions collects optional Container App settings passed to the tool.
+// Settings that were not passed stay nil so they don't override the current state on update.
+// The returned options are validated.
+func (s *MCPServer) getContainerAppOptions(request mcp.CallToolRequest) (domain.ContainerAppOptions, error) {
+	var options domain.ContainerAppOptions
+
+	publiclyAccessibleStr, err := s.getMCPFieldValue("publicly_accessible", request)
+	if err != nil {
+		return options, err
+	}
+	if publiclyAccessibleStr != "" {
+		publiclyAccessible, err := parseBoolField("publicly_accessible", publiclyAccessibleStr)
+		if err != nil {
+			return options, err
+		}
+		options.PubliclyAccessible = &publiclyAccessible
+	}
+
+	portMappingsStr, err := s.getMCPFieldValue("additional_port_mappings", request)
+	if err != nil {
+		return options, err
+	}
+	if portMappingsStr != "" {
+		portMappings := []domain.PortMapping{}
+		if err := parseJSONField("additional_port_mappings", portMappingsStr, &portMappings); err != nil {
+			return options, err
+		}
+		options.AdditionalPortMappings = portMappings
+	}
+
+	volumesStr, err := s.getMCPFieldValue("volumes", request)
+	if err != nil {
+		return options, err
+	}
+	if volumesStr != "" {
+		volumes := []domain.Volume{}
+		if err := parseJSONField("volumes", volumesStr, &volumes); err != nil {
+			return options, err
+		}
+		options.Volumes = volumes
+	}
+
+	volumeMountsStr, err := s.getMCPFieldValue("volume_mounts", request)
+	if err != nil {
+		return options, err
+	}
+	if volumeMountsStr != "" {
+		volumeMounts := []domain.VolumeMount{}
+		if err := parseJSONField("volume_mounts", volumeMountsStr, &volumeMounts); err != nil {
+			return options, err
+		}
+		options.VolumeMounts = volumeMounts
+	}
+
+	commandStr, err := s.getMCPFieldValue("command", request)
+	if err != nil {
+		return options, err
+	}
+	if commandStr != "" {
+		if options.Command, err = parseStringListField("command", commandStr); err != nil {
+			return options, err
+		}
+	}
+
+	argsStr, err := s.getMCPFieldValue("args", request)
+	if err != nil {
+		return options, err
+	}
+	if argsStr != "" {
+		if options.Args, err = parseStringListField("args", argsStr); err != nil {
+			return options, err
+		}
+	}
+
+	initContainersStr, err := s.getMCPFieldValue("init_containers", request)
+	if err != nil {
+		return options, err
+	}
+	if initContainersStr != "" {
+		initContainers := []domain.Container{}
+		if err := parseJSONField("init_containers", initContainersStr, &initContainers); err != nil {
+			return options, err
+		}
+		options.InitContainers = initContainers
+	}
+
+	if err := s.getAutoDeploymentsOptions(request, &options); err != nil {
+		return options, err
+	}
+
+	timeoutStr, err := s.getMCPFieldValue("timeout", request)
+	if err != nil {
+		return options, err
+	}
+	if timeoutStr != "" {
+		timeout, err := parseDurationField("timeout", timeoutStr)
+		if err != nil {
+			return options, err
+		}
+		options.Timeout = &timeout
+	}
+
+	idleTimeoutStr, err := s.getMCPFieldValue("idle_timeout", request)
+	if err != nil {
+		return options, err
+	}
+	if idleTimeoutStr != "" {
+		idleTimeout, err := parseDurationField("idle_timeout", idleTimeoutStr)
+		if err != nil {
+			return options, err
+		}
+		options.IdleTimeout = &idleTimeout
+	}
+
+	protocol, err := s.getMCPFieldValue("protocol", request)
+	if err != nil {
+		return options, err
+	}
+	if protocol != "" {
+		protocol = strings.ToLower(protocol)
+		options.Protocol = &protocol
+	}
+
+	sidecarsStr, err := s.getMCPFieldValue("sidecars", request)
+	if err != nil {
+		return options, err
+	}
+	if sidecarsStr != "" {
+		sidecars := []domain.Container{}
+		if err := parseJSONField("sidecars", sidecarsStr, &sidecars); err != nil {
+			return options, err
+		}
+		options.Sidecars = sidecars
+	}
+
+	ingressContainer, err := s.getMCPFieldValue("ingress_container", request)
+	if err != nil {
+		return options, err
+	}
+	if ingressContainer != "" {
+		options.IngressContainer = &ingressContainer
+	}
+
+	description, err := s.getMCPFieldValue("containerapp_description", request)
+	if err != nil {
+		return options, err
+	}
+	// An explicitly passed empty description clears it
+	if description != "" || s.isMCPFieldPassed("containerapp_description", request) {
+		options.Description = &description
+	}
+
+	labelsStr, err := s.getMCPFieldValue("labels", request)
+	if err != nil {
+		return options, err
+	}
+	if labelsStr != "" {
+		labels, err := parseLabelsField("labels", labelsStr)
+		if err != nil {
+			return options, err
+		}
+		options.Labels = labels
+	}
+
+	// Options are validated here so that invalid values are rejected before any call to the API
+	return options, options.Validate()
+}
+
+// getAutoDeploymentsOptions collects only the auto-deployment settings passed to the tool
+func (s *MCPServer) getAutoDeploymentsOptions(request mcp.CallToolRequest, options *domain.ContainerAppOptions) error {
+	autoDeploymentsEnabledStr, err := s.getMCPFieldValue("autodeployments_enabled", request)
+	if err != nil {
+		return err
+	}
+	if autoDeploymentsEnabledStr != "" {
+		autoDeploymentsEnabled, err := parseBoolField("autodeployments_enabled", autoDeploymentsEnabledStr)
+		if err != nil {
+			return err
+		}
+		options.AutoDeploymentsEnabled = &autoDeploymentsEnabled
+	}
+
+	autoDeploymentsPattern, err := s.getMCPFieldValue("autodeployments_pattern", request)
+	if err != nil {
+		return err
+	}
+	if autoDeploymentsPattern != "" {
+		options.AutoDeploymentsPattern = &autoDeploymentsPattern
+	}
+
+	return nil
+}
+
+// getContainerAppFilter collects the Container App filter criteria passed to the tool
+func (s *MCPServer) getContainerAppFilter(request mcp.CallToolRequest) (*domain.ContainerAppFilter, error) {
+	criteria := map[string]string{}
+	for _, field := range []string{"name_pattern", "name_regex", "status", "image_contains", "visibility", "label_selector"} {
+		value, err := s.getMCPFieldValue(field, request)
+		if err != nil {
+			return nil, err
+		}
+		criteria[field] = value
+	}
+
+	return domain.NewContainerAppFilter(
+		criteria["name_pattern"],
+		criteria["name_regex"],
+		criteria["status"],
+		criteria["image_contains"],
+		criteria["visibility"],
+		criteria["label_selector"],
+	)
+}
+
+// parseDurationField converts a tool argument such as 30s or 5m into a duration. A bare number is treated as seconds
+func parseDurationField(field string, value string) (time.Duration, error) {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a duration such as 30s or 5m: %w", field, err)
+	}
+	return duration, nil
+}
+
+// parseJSONField decodes a tool argument passed as a JSON document
+func parseJSONField(field string, value string, target interface{}) error {
+	if err := json.Unmarshal([]byte(value), target); err != nil {
+		return fmt.Errorf("%s must be valid JSON: %w", field, err)
+	}
+	return nil
+}
+
+// parseLabelsField decodes labels passed either as a JSON object or as key=value pairs separated by commas
+func parseLabelsField(field string, value string) (map[string]string, error) {
+	labels := map[string]string{}
+	if strings.HasPrefix(strings.TrimSpace(value), "{") {
+		if err := parseJSONField(field, value, &labels); err != nil {
+			return nil, err
+		}
+		return labels, nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		key, labelValue, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if !found || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("%s must be a JSON object or key=value pairs separated by commas, got %q", field, pair)
+		}
+		labels[strings.TrimSpace(key)] = strings.TrimSpace(labelValue)
+	}
+	return labels, nil
+}
+
+// parseBoolField converts a tool argument into a boolean value
+func parseBoolField(field string, value string) (bool, error) {
+	switch value {
+	case "true", "1":
+		return true, nil
+	case "false", "0":
+		return false, nil
+	default:
+		return false, fmt.Errorf("%s must be 'true' or 'false'", field)
+	}
+}
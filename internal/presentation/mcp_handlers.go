@@ -4,6 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/config"
 	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
@@ -18,6 +21,7 @@ type MCPServer struct {
 	dockerService         domain.DockerService
 	containerAppsService  domain.ContainerAppsService
 	dockerRegistryService domain.DockerRegistryService
+	schedulerService      domain.SchedulerService
 
 	mappedFields map[string]struct {
 		envValue     string
@@ -30,7 +34,7 @@ type MCPServer struct {
 }
 
 // NewMCPServer creates a new MCP server with the required services
-func NewMCPServer(descriptionService domain.DescriptionService, dockerService domain.DockerService, containerAppsService domain.ContainerAppsService, dockerRegistryService domain.DockerRegistryService) *MCPServer {
+func NewMCPServer(descriptionService domain.DescriptionService, dockerService domain.DockerService, containerAppsService domain.ContainerAppsService, dockerRegistryService domain.DockerRegistryService, schedulerService domain.SchedulerService) *MCPServer {
 	cfg := config.LoadConfig()
 
 	defaultRepoName := cfg.CurrentDir
@@ -44,6 +48,7 @@ func NewMCPServer(descriptionService domain.DescriptionService, dockerService do
 		dockerService:         dockerService,
 		containerAppsService:  containerAppsService,
 		dockerRegistryService: dockerRegistryService,
+		schedulerService:      schedulerService,
 		cfg:                   cfg,
 
 		mappedFields: map[string]struct {
@@ -109,6 +114,259 @@ func NewMCPServer(descriptionService domain.DescriptionService, dockerService do
 				required:    true,
 				title:       "Example image: " + containerappImage,
 			},
+			"publicly_accessible": {
+				description: "Whether the Container App is reachable from the internet: true or false (false creates an internal-only app)",
+				required:    false,
+			},
+			"additional_port_mappings": {
+				description: "Additional ports exposed by the Container App as a JSON array, use [] to remove all mappings",
+				required:    false,
+				title:       `Example: [{"port": 9090, "containerPort": 9090}]`,
+			},
+			"volumes": {
+				description: "Object Storage buckets attached to the Container App as a JSON array, use [] to remove all volumes",
+				required:    false,
+				title:       `Example: [{"name": "assets", "volumeAttributes": {"bucketName": "my-bucket", "tenantId": "<tenant_id>", "region": "ru-central-1", "entrypoint": "https://s3.cloud.ru"}}]`,
+			},
+			"volume_mounts": {
+				description: "Mount paths of the volumes in the container as a JSON array, use [] to remove all mounts",
+				required:    false,
+				title:       `Example: [{"name": "assets", "mountPath": "/data", "readOnly": true}]`,
+			},
+			"command": {
+				description: "Command that overrides the image entrypoint, as a shell-style string or a JSON array of strings, use [] to reset to the image default",
+				required:    false,
+				title:       `Example: python -m worker --queue "high priority"`,
+			},
+			"args": {
+				description: "Arguments passed to the command, as a shell-style string or a JSON array of strings, use [] to reset to the image default",
+				required:    false,
+				title:       `Example: ["--port", "8000"]`,
+			},
+			"autodeployments_enabled": {
+				description: "Whether the Container App is redeployed automatically when an image tag matching autodeployments_pattern is pushed: true or false",
+				required:    false,
+			},
+			"autodeployments_pattern": {
+				description: "Image tag pattern that triggers auto-deployment",
+				required:    false,
+				title:       "Example: v* or main-*",
+			},
+			"timeout": {
+				description: "Maximum time to process a request, as a duration such as 30s or 5m (up to 1h)",
+				required:    false,
+				title:       "Example: 5m",
+			},
+			"idle_timeout": {
+				description: "Maximum time a connection may stay idle, as a duration such as 30s or 5m (up to 1h)",
+				required:    false,
+				title:       "Example: 10m",
+			},
+			"protocol": {
+				description: "Protocol used to reach the container: http1, http2 or grpc",
+				required:    false,
+				title:       "Use grpc for gRPC services",
+			},
+			"target_containerapp_name": {
+				description: "Name of the Container App copy",
+				required:    true,
+			},
+			"target_project_id": {
+				description: "Project ID to create the copy in, defaults to the project of the source Container App",
+				required:    false,
+			},
+			"target_containerapp_image": {
+				description: "Image for the main container of the copy, defaults to the image of the source Container App",
+				required:    false,
+			},
+			"env": {
+				description: "Environment variables of the main container to set or override, as a JSON array",
+				required:    false,
+				title:       `Example: [{"name": "TENANT", "value": "acme"}]`,
+			},
+			"copy_secrets": {
+				description:  "Whether secret environment variables are carried over to the copy: true or false",
+				required:     false,
+				defaultValue: "false",
+			},
+			"schedule_name": {
+				description: "Name of the schedule",
+				required:    true,
+				title:       "For example: dev-nights",
+			},
+			"start_cron": {
+				description: "When to start the Container Apps, as a five-field cron expression: minute hour day-of-month month day-of-week",
+				required:    false,
+				title:       "For example: 0 8 * * mon-fri",
+			},
+			"stop_cron": {
+				description: "When to stop the Container Apps, as a five-field cron expression: minute hour day-of-month month day-of-week",
+				required:    false,
+				title:       "For example: 0 20 * * mon-fri",
+			},
+			"timezone": {
+				description:  "IANA time zone the cron expressions are evaluated in",
+				required:     false,
+				defaultValue: "UTC",
+				title:        "For example: Europe/Moscow",
+			},
+			"operation_id": {
+				description: "ID of the asynchronous operation, as returned by create, update, delete, start or stop",
+				required:    true,
+			},
+			"confirm_registry_name": {
+				description: "Exact name of the Docker Registry to delete, repeated to confirm the deletion",
+				required:    true,
+			},
+			"keep_last_tags": {
+				description: "Retention rule: keep the last N pushed tags of every repository",
+				required:    false,
+				title:       "Example: 10",
+			},
+			"delete_untagged": {
+				description: "Retention rule: delete images without tags: true or false",
+				required:    false,
+			},
+			"delete_older_than_days": {
+				description: "Retention rule: delete tags pushed more than X days ago. Combined with keep_last_tags, only tags matching both rules are deleted",
+				required:    false,
+				title:       "Example: 30",
+			},
+			"protected_tag_patterns": {
+				description: "Comma-separated glob patterns of tags the retention policy never deletes",
+				required:    false,
+				title:       "Example: v*,latest,release-*",
+			},
+			"preview": {
+				description:  "Only show which images the retention policy would delete without deleting them: true or false. Run with true first",
+				required:     false,
+				defaultValue: "true",
+			},
+			"tag_or_digest": {
+				description: "Image tag, or image digest to delete together with all its tags",
+				required:    true,
+				title:       "Example: v1.2.3 or sha256:4f53cda18c2baa0c0354bb5f9a3ecbe5ed12ab4d8e11ba873c2f11161202b945",
+			},
+			"confirm_tag_or_digest": {
+				description: "Exact tag or digest to delete, repeated to confirm the deletion",
+				required:    true,
+			},
+			"force": {
+				description:  "Go ahead even if Container Apps use the images that would be deleted: true or false. Those apps can't pull their images anymore",
+				required:     false,
+				defaultValue: "false",
+			},
+			"domain_name": {
+				description: "Custom domain name served by the Container App",
+				required:    true,
+				title:       "Example: api.example.com",
+			},
+			"confirm_domain_name": {
+				description: "Exact custom domain name to detach, repeated to confirm",
+				required:    true,
+			},
+			"if_exists": {
+				description:  "What to do if the Container App already exists: error, skip (keep it as is) or update (set image, port and passed settings)",
+				required:     false,
+				defaultValue: domain.IfExistsError,
+				title:        "Use update to make repeated deploys converge",
+			},
+			"manifest_format": {
+				description:  "Format of the exported manifest: yaml or json",
+				required:     false,
+				defaultValue: domain.ManifestFormatYAML,
+			},
+			"output_path": {
+				description: "File to write the manifest to, inside the current directory. The manifest is returned whether it is written or not",
+				required:    false,
+				title:       "Example: .cloudru/" + cfg.CurrentDir + ".yaml",
+			},
+			"overwrite": {
+				description:  "Replace the output_path file if it already exists: true or false",
+				required:     false,
+				defaultValue: "false",
+			},
+			"name_pattern": {
+				description: "Only return Container Apps whose name matches this glob pattern",
+				required:    false,
+				title:       "Example: dev-*",
+			},
+			"name_regex": {
+				description: "Only return Container Apps whose name matches this regular expression",
+				required:    false,
+				title:       "Example: ^(dev|staging)-",
+			},
+			"status": {
+				description: "Only return Container Apps with this status",
+				required:    false,
+				title:       "Example: RUNNING",
+			},
+			"image_contains": {
+				description: "Only return Container Apps whose image contains this substring",
+				required:    false,
+				title:       "Example: my-registry.cr.cloud.ru/backend",
+			},
+			"visibility": {
+				description: "Only return public or private (internal-only) Container Apps: public or private",
+				required:    false,
+			},
+			"label_selector": {
+				description: "Only return Container Apps with these labels, as key=value pairs separated by commas. A key without a value matches any value",
+				required:    false,
+				title:       "Example: team=billing,env=dev",
+			},
+			"containerapp_description": {
+				description: "Description of the Container App, up to 255 characters. On update an empty value clears it",
+				required:    false,
+				title:       "Example: Billing API, owned by the billing team",
+			},
+			"labels": {
+				description: "Labels as a JSON object or key=value pairs separated by commas (team, owner, git SHA, environment). On update the labels are merged into the current ones, an empty value removes a label",
+				required:    false,
+				title:       "Example: team=billing,owner=alice,git-sha=3f2a1bc,env=dev",
+			},
+			"summary": {
+				description:  "Return a compact summary (name, status, image, URI, min/max instances, labels) instead of full Container Apps: true or false",
+				required:     false,
+				defaultValue: "false",
+			},
+			"confirm_containerapp_name": {
+				description: "Exact name of the Container App to delete, repeated to confirm the deletion",
+				required:    true,
+			},
+			"confirm_containerapp_names": {
+				description: "Comma-separated exact names of all Container Apps to delete, as listed by the dry run. Required for bulk delete",
+				required:    false,
+			},
+			"bulk_action": {
+				description: "Action to apply to every matching Container App: start, stop or delete",
+				required:    true,
+			},
+			"dry_run": {
+				description:  "Only preview which Container Apps would be affected without changing them: true or false. Run with true first",
+				required:     false,
+				defaultValue: "true",
+			},
+			"concurrency": {
+				description:  "How many Container Apps are changed at the same time (1-10)",
+				required:     false,
+				defaultValue: "4",
+			},
+			"sidecars": {
+				description: "Sidecar containers running next to the main container (log shippers, auth proxies) as a JSON array, use [] to remove all sidecars",
+				required:    false,
+				title:       `Example: [{"name": "auth-proxy", "image": "<registry>.cr.cloud.ru/auth-proxy:v1", "containerPort": 4180, "env": [{"name": "UPSTREAM", "value": "http://localhost:8000"}], "resources": {"cpu": "0.25", "memory": "256Mi"}}]`,
+			},
+			"ingress_container": {
+				description: "Name of the container that receives ingress traffic, defaults to the main container",
+				required:    false,
+				title:       "Example: auth-proxy",
+			},
+			"init_containers": {
+				description: "Containers that run to completion before the main container starts (migrations, config fetching) as a JSON array, use [] to remove all init containers",
+				required:    false,
+				title:       `Example: [{"name": "migrate", "image": "<registry>.cr.cloud.ru/app:v1", "command": ["./manage.py", "migrate"], "env": [{"name": "DEBUG", "value": "0"}], "resources": {"cpu": "0.5", "memory": "512Mi"}}]`,
+			},
 		},
 	}
 }
@@ -138,6 +396,15 @@ func (s *MCPServer) getMCPFieldsOptions(description string, fields ...string) []
 	return result
 }
 
+// isMCPFieldPassed reports whether the field was passed in the request, even with an empty value
+func (s *MCPServer) isMCPFieldPassed(field string, request mcp.CallToolRequest) bool {
+	if s.mappedFields[field].envValue != "" {
+		return false
+	}
+	_, ok := request.GetArguments()[field]
+	return ok
+}
+
 func (s *MCPServer) getMCPFieldValue(field string, request mcp.CallToolRequest) (string, error) {
 	fieldData := s.mappedFields[field]
 	// If we have an environment variable value, use it
@@ -147,7 +414,7 @@ func (s *MCPServer) getMCPFieldValue(field string, request mcp.CallToolRequest)
 
 	// Try to get the value from the request
 	result, err := request.RequireString(field)
-	if err != nil && fieldData.defaultValue == "" {
+	if err != nil && fieldData.defaultValue == "" && fieldData.required {
 		// If there's an error and no default or env value, return the error
 		return "", err
 	}
@@ -194,6 +461,9 @@ func (s *MCPServer) RegisterDockerLoginTool(server *server.MCPServer) {
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
+		if err := domain.ValidateRegistryName(registryName); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
 
 		credentials := domain.Credentials{
 			KeyID:     s.cfg.KeyID,
@@ -227,13 +497,25 @@ func (s *MCPServer) RegisterDockerPushTool(server *server.MCPServer) {
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
+		if err := domain.ValidateRegistryName(registryName); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
 
 		repositoryName, err := s.getMCPFieldValue("repository_name", request)
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
+		if err := domain.ValidateRepositoryName(repositoryName); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
 		imageVersion, _ := s.getMCPFieldValue("image_version", request)
+		if imageVersion != "" {
+			if err := domain.ValidateImageTag(imageVersion); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		}
 		dockerfilePath, _ := request.RequireString("dockerfile_path")
 		dockerfileTarget, _ := request.RequireString("dockerfile_target")
 		dockerfileFolder, _ := request.RequireString("dockerfile_folder")
@@ -267,8 +549,15 @@ func (s *MCPServer) RegisterDockerPushTool(server *server.MCPServer) {
 func (s *MCPServer) RegisterGetListContainerAppsTool(server *server.MCPServer) {
 	// Prepare tool options including description and fields
 	toolOptions := s.getMCPFieldsOptions(
-		"Get list of Container Apps from Cloud.ru. Project ID can be set via PROJECT_ID environment variable and obtained from console.cloud.ru",
+		"Get list of Container Apps from Cloud.ru, optionally filtered by name, status, image, visibility and labels. Use summary=true to get a compact listing. Project ID can be set via PROJECT_ID environment variable and obtained from console.cloud.ru",
 		"project_id",
+		"name_pattern",
+		"name_regex",
+		"status",
+		"image_contains",
+		"visibility",
+		"label_selector",
+		"summary",
 	)
 	getListContainerAppsTool := mcp.NewTool("cloudru_get_list_containerapps", toolOptions...)
 
@@ -284,14 +573,39 @@ func (s *MCPServer) RegisterGetListContainerAppsTool(server *server.MCPServer) {
 			KeySecret: s.cfg.KeySecret,
 		}
 
+		// Get filter criteria
+		filter, err := s.getContainerAppFilter(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		summaryStr, err := s.getMCPFieldValue("summary", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		summary, err := parseBoolField("summary", summaryStr)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
 		// Call the service
 		containerApps, err := s.containerAppsService.GetListContainerApps(projectID, credentials)
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
+		containerApps = filter.Filter(containerApps)
+
+		var output interface{} = containerApps
+		if summary {
+			summaries := []domain.ContainerAppSummary{}
+			for _, containerApp := range containerApps {
+				summaries = append(summaries, containerApp.Summary())
+			}
+			output = summaries
+		}
 
 		// Convert to JSON for output
-		result, err := json.MarshalIndent(containerApps, "", "  ")
+		result, err := json.MarshalIndent(output, "", "  ")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to format result: %v", err)), nil
 		}
@@ -304,7 +618,7 @@ func (s *MCPServer) RegisterGetListContainerAppsTool(server *server.MCPServer) {
 func (s *MCPServer) RegisterGetContainerAppTool(server *server.MCPServer) {
 	// Prepare tool options including description and fields
 	toolOptions := s.getMCPFieldsOptions(
-		"Get a specific Container App from Cloud.ru by name. Project ID can be set via PROJECT_ID environment variable and obtained from console.cloud.ru",
+		"Get a specific Container App from Cloud.ru by name, including its containers and init containers. Project ID can be set via PROJECT_ID environment variable and obtained from console.cloud.ru",
 		"project_id",
 		"containerapp_name",
 	)
@@ -322,6 +636,9 @@ func (s *MCPServer) RegisterGetContainerAppTool(server *server.MCPServer) {
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
+		if err := domain.ValidateContainerAppName(containerAppName); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
 
 		credentials := domain.Credentials{
 			KeyID:     s.cfg.KeyID,
@@ -348,11 +665,28 @@ func (s *MCPServer) RegisterGetContainerAppTool(server *server.MCPServer) {
 func (s *MCPServer) RegisterCreateContainerAppTool(server *server.MCPServer) {
 	// Prepare tool options including description and fields
 	toolOptions := s.getMCPFieldsOptions(
-		"Create a new Container App in Cloud.ru",
+		"Create a new Container App in Cloud.ru. if_exists decides what happens when it already exists: error, skip or update",
 		"project_id",
 		"containerapp_name",
 		"containerapp_port",
 		"containerapp_image",
+		"publicly_accessible",
+		"additional_port_mappings",
+		"volumes",
+		"volume_mounts",
+		"command",
+		"args",
+		"init_containers",
+		"autodeployments_enabled",
+		"autodeployments_pattern",
+		"timeout",
+		"idle_timeout",
+		"protocol",
+		"sidecars",
+		"ingress_container",
+		"containerapp_description",
+		"labels",
+		"if_exists",
 	)
 	createContainerAppTool := mcp.NewTool("cloudru_create_containerapp", toolOptions...)
 
@@ -376,8 +710,10 @@ func (s *MCPServer) RegisterCreateContainerAppTool(server *server.MCPServer) {
 		}
 
 		// Convert port to integer
-		var containerAppPort int
-		fmt.Sscanf(containerAppPortStr, "%d", &containerAppPort)
+		containerAppPort, err := strconv.Atoi(strings.TrimSpace(containerAppPortStr))
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("containerapp_port must be a number, got %q", containerAppPortStr)), nil
+		}
 
 		// Get container app image
 		containerAppImage, err := s.getMCPFieldValue("containerapp_image", request)
@@ -385,38 +721,86 @@ func (s *MCPServer) RegisterCreateContainerAppTool(server *server.MCPServer) {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
+		// Validate before any API call, so mistakes are reported precisely and without a round trip
+		if err := domain.ValidateContainerAppName(containerAppName); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if err := domain.ValidatePort("containerapp_port", containerAppPort); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if err := domain.ValidateImageReference(containerAppImage); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get optional container app settings
+		options, err := s.getContainerAppOptions(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		ifExists, err := s.getMCPFieldValue("if_exists", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
 		credentials := domain.Credentials{
 			KeyID:     s.cfg.KeyID,
 			KeySecret: s.cfg.KeySecret,
 		}
 
 		// Call the service
-		containerApp, err := s.containerAppsService.CreateContainerApp(projectID, containerAppName, containerAppPort, containerAppImage, credentials)
+		createResult, err := s.containerAppsService.CreateOrUpdateContainerApp(projectID, containerAppName, containerAppPort, containerAppImage, options, strings.ToLower(ifExists), credentials)
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
 		// Convert to JSON for output
-		result, err := json.MarshalIndent(containerApp, "", "  ")
+		result, err := json.MarshalIndent(createResult.ContainerApp, "", "  ")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to format result: %v", err)), nil
 		}
 
-		return mcp.NewToolResultText(fmt.Sprintf("Successfully created Container App: %s\n%s", containerAppName, string(result))), nil
+		var message string
+		switch createResult.Action {
+		case domain.CreateActionSkipped:
+			message = fmt.Sprintf("Container App %s already exists, left unchanged", containerAppName)
+		case domain.CreateActionUpdated:
+			message = fmt.Sprintf("Container App %s already exists, successfully updated it", containerAppName)
+		default:
+			message = fmt.Sprintf("Successfully created Container App: %s", containerAppName)
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("%s%s\n%s", message, formatOperationID(createResult.ContainerApp.OperationID), string(result))), nil
 	})
 }
 
-// RegisterDeleteContainerAppTool registers the delete container app tool with the MCP server
-func (s *MCPServer) RegisterDeleteContainerAppTool(server *server.MCPServer) {
+// RegisterUpdateContainerAppTool registers the update container app tool with the MCP server
+func (s *MCPServer) RegisterUpdateContainerAppTool(server *server.MCPServer) {
 	// Prepare tool options including description and fields
 	toolOptions := s.getMCPFieldsOptions(
-		"Delete a Container App from Cloud.ru. WARNING: This action cannot be undone!",
+		"Update settings of an existing Container App in Cloud.ru. Only the passed settings are changed",
 		"project_id",
 		"containerapp_name",
+		"publicly_accessible",
+		"additional_port_mappings",
+		"volumes",
+		"volume_mounts",
+		"command",
+		"args",
+		"init_containers",
+		"autodeployments_enabled",
+		"autodeployments_pattern",
+		"timeout",
+		"idle_timeout",
+		"protocol",
+		"sidecars",
+		"ingress_container",
+		"containerapp_description",
+		"labels",
 	)
-	deleteContainerAppTool := mcp.NewTool("cloudru_delete_containerapp", toolOptions...)
+	updateContainerAppTool := mcp.NewTool("cloudru_update_containerapp", toolOptions...)
 
-	server.AddTool(deleteContainerAppTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	server.AddTool(updateContainerAppTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Get project ID
 		projectID, err := s.getMCPFieldValue("project_id", request)
 		if err != nil {
@@ -428,36 +812,54 @@ func (s *MCPServer) RegisterDeleteContainerAppTool(server *server.MCPServer) {
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
+		if err := domain.ValidateContainerAppName(containerAppName); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get container app settings to change
+		options, err := s.getContainerAppOptions(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
 
 		credentials := domain.Credentials{
 			KeyID:     s.cfg.KeyID,
 			KeySecret: s.cfg.KeySecret,
 		}
 
-		// Confirmation prompt - in MCP context, we'll add a warning in the description
-		// but the actual confirmation would typically happen in the client UI
-
 		// Call the service
-		err = s.containerAppsService.DeleteContainerApp(projectID, containerAppName, credentials)
+		containerApp, err := s.containerAppsService.UpdateContainerApp(projectID, containerAppName, options, credentials)
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		return mcp.NewToolResultText(fmt.Sprintf("Successfully deleted Container App: %s", containerAppName)), nil
+		// Convert to JSON for output
+		result, err := json.MarshalIndent(containerApp, "", "  ")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to format result: %v", err)), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Successfully updated Container App: %s%s\n%s", containerAppName, formatOperationID(containerApp.OperationID), string(result))), nil
 	})
 }
 
-// RegisterStartContainerAppTool registers the start container app tool with the MCP server
-func (s *MCPServer) RegisterStartContainerAppTool(server *server.MCPServer) {
+// RegisterSetContainerAppAutoDeploymentsTool registers the set container app auto-deployments tool with the MCP server
+func (s *MCPServer) RegisterSetContainerAppAutoDeploymentsTool(server *server.MCPServer) {
 	// Prepare tool options including description and fields
 	toolOptions := s.getMCPFieldsOptions(
-		"Start a Container App in Cloud.ru",
+		"Enable or disable auto-deployment of a Container App in Cloud.ru. When enabled, pushing an image tag matching the pattern (for example with cloudru_docker_push) redeploys the app automatically",
 		"project_id",
 		"containerapp_name",
+		"autodeployments_pattern",
 	)
-	startContainerAppTool := mcp.NewTool("cloudru_start_containerapp", toolOptions...)
-
-	server.AddTool(startContainerAppTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// autodeployments_enabled is optional for create and update, but this tool can't do anything without it
+	toolOptions = append(toolOptions, mcp.WithString("autodeployments_enabled",
+		mcp.Description(s.mappedFields["autodeployments_enabled"].description),
+		mcp.Required(),
+	))
+	setAutoDeploymentsTool := mcp.NewTool("cloudru_set_containerapp_autodeployments", toolOptions...)
+
+	server.AddTool(setAutoDeploymentsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Get project ID
 		projectID, err := s.getMCPFieldValue("project_id", request)
 		if err != nil {
@@ -469,6 +871,21 @@ func (s *MCPServer) RegisterStartContainerAppTool(server *server.MCPServer) {
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
+		if err := domain.ValidateContainerAppName(containerAppName); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get auto-deployment settings, other Container App settings are ignored by this tool
+		var options domain.ContainerAppOptions
+		if err := s.getAutoDeploymentsOptions(request, &options); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if options.AutoDeploymentsEnabled == nil {
+			return mcp.NewToolResultError("autodeployments_enabled must be 'true' or 'false'"), nil
+		}
+		if err := options.Validate(); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
 
 		credentials := domain.Credentials{
 			KeyID:     s.cfg.KeyID,
@@ -476,128 +893,178 @@ func (s *MCPServer) RegisterStartContainerAppTool(server *server.MCPServer) {
 		}
 
 		// Call the service
-		err = s.containerAppsService.StartContainerApp(projectID, containerAppName, credentials)
+		containerApp, err := s.containerAppsService.UpdateContainerApp(projectID, containerAppName, options, credentials)
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		return mcp.NewToolResultText(fmt.Sprintf("Successfully started Container App: %s", containerAppName)), nil
+		autoDeployments := containerApp.Configuration.AutoDeployments
+		if !autoDeployments.Enabled {
+			return mcp.NewToolResultText(fmt.Sprintf("Successfully disabled auto-deployments for Container App: %s", containerAppName)), nil
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("Successfully enabled auto-deployments for Container App: %s with tag pattern: %s", containerAppName, autoDeployments.Pattern)), nil
 	})
 }
 
-// RegisterStopContainerAppTool registers the stop container app tool with the MCP server
-func (s *MCPServer) RegisterStopContainerAppTool(server *server.MCPServer) {
+// RegisterCloneContainerAppTool registers the clone container app tool with the MCP server
+func (s *MCPServer) RegisterCloneContainerAppTool(server *server.MCPServer) {
 	// Prepare tool options including description and fields
 	toolOptions := s.getMCPFieldsOptions(
-		"Stop a Container App in Cloud.ru",
+		"Create a copy of a Container App in Cloud.ru under a new name or in another project. Secret environment variables are only copied when copy_secrets is true",
 		"project_id",
 		"containerapp_name",
+		"target_containerapp_name",
+		"target_project_id",
+		"target_containerapp_image",
+		"env",
+		"copy_secrets",
 	)
-	stopContainerAppTool := mcp.NewTool("cloudru_stop_containerapp", toolOptions...)
+	cloneContainerAppTool := mcp.NewTool("cloudru_clone_containerapp", toolOptions...)
 
-	server.AddTool(stopContainerAppTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	server.AddTool(cloneContainerAppTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Get project ID
 		projectID, err := s.getMCPFieldValue("project_id", request)
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// Get container app name
+		// Get source container app name
 		containerAppName, err := s.getMCPFieldValue("containerapp_name", request)
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
+		if err := domain.ValidateContainerAppName(containerAppName); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
 
-		credentials := domain.Credentials{
-			KeyID:     s.cfg.KeyID,
-			KeySecret: s.cfg.KeySecret,
+		// Get the copy settings
+		targetName, err := s.getMCPFieldValue("target_containerapp_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if err := domain.ValidateContainerAppName(targetName); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// Call the service
-		err = s.containerAppsService.StopContainerApp(projectID, containerAppName, credentials)
+		targetProjectID, err := s.getMCPFieldValue("target_project_id", request)
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		return mcp.NewToolResultText(fmt.Sprintf("Successfully stopped Container App: %s", containerAppName)), nil
-	})
-}
+		targetImage, err := s.getMCPFieldValue("target_containerapp_image", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if targetImage != "" {
+			if err := domain.ValidateImageReference(targetImage); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		}
 
-// RegisterGetListDockerRegistriesTool registers the get list docker registries tool with the MCP server
-func (s *MCPServer) RegisterGetListDockerRegistriesTool(server *server.MCPServer) {
-	// Prepare tool options including description and fields
-	toolOptions := s.getMCPFieldsOptions(
-		"Get list of Docker Registries from Cloud.ru. Project ID can be set via PROJECT_ID environment variable and obtained from console.cloud.ru",
-		"project_id",
-	)
-	getListDockerRegistriesTool := mcp.NewTool("cloudru_get_list_docker_registries", toolOptions...)
+		envStr, err := s.getMCPFieldValue("env", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		env := []domain.EnvVar{}
+		if envStr != "" {
+			if err := parseJSONField("env", envStr, &env); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		}
+		if err := domain.ValidateEnvVars(env); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
 
-	server.AddTool(getListDockerRegistriesTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Get project ID
-		projectID, err := s.getMCPFieldValue("project_id", request)
+		copySecretsStr, err := s.getMCPFieldValue("copy_secrets", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		copySecrets, err := parseBoolField("copy_secrets", copySecretsStr)
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
+		options := domain.CloneOptions{
+			TargetProjectID: targetProjectID,
+			TargetName:      targetName,
+			Image:           targetImage,
+			Env:             env,
+			CopySecrets:     copySecrets,
+		}
+
 		credentials := domain.Credentials{
 			KeyID:     s.cfg.KeyID,
 			KeySecret: s.cfg.KeySecret,
 		}
 
 		// Call the service
-		dockerRegistries, err := s.dockerRegistryService.GetListDockerRegistries(projectID, credentials)
+		cloneResult, err := s.containerAppsService.CloneContainerApp(projectID, containerAppName, options, credentials)
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
 		// Convert to JSON for output
-		result, err := json.MarshalIndent(dockerRegistries, "", "  ")
+		result, err := json.MarshalIndent(cloneResult.ContainerApp, "", "  ")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to format result: %v", err)), nil
 		}
 
-		return mcp.NewToolResultText(string(result)), nil
+		message := fmt.Sprintf("Successfully cloned Container App %s as %s%s", containerAppName, targetName, formatOperationID(cloneResult.ContainerApp.OperationID))
+		if len(cloneResult.SkippedSecretEnv) > 0 {
+			message += fmt.Sprintf("\nSecret environment variables were not copied (set copy_secrets to true to copy them): %s", strings.Join(cloneResult.SkippedSecretEnv, ", "))
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("%s\n%s", message, string(result))), nil
 	})
 }
 
-// RegisterCreateDockerRegistryTool registers the create docker registry tool with the MCP server
-func (s *MCPServer) RegisterCreateDockerRegistryTool(server *server.MCPServer) {
+// RegisterExportContainerAppTool registers the export container app tool with the MCP server
+func (s *MCPServer) RegisterExportContainerAppTool(server *server.MCPServer) {
 	// Prepare tool options including description and fields
 	toolOptions := s.getMCPFieldsOptions(
-		"Create a new Docker Registry in Cloud.ru",
+		"Export a Container App from Cloud.ru to a YAML or JSON manifest, so it can be managed as code. Empty values and server-assigned fields are stripped, secret values are replaced by ${NAME} placeholders",
 		"project_id",
-		"registry_name",
-		"is_public",
+		"containerapp_name",
+		"manifest_format",
+		"output_path",
+		"overwrite",
 	)
-	createDockerRegistryTool := mcp.NewTool("cloudru_create_docker_registry", toolOptions...)
+	exportContainerAppTool := mcp.NewTool("cloudru_export_containerapp", toolOptions...)
 
-	server.AddTool(createDockerRegistryTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	server.AddTool(exportContainerAppTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		// Get project ID
 		projectID, err := s.getMCPFieldValue("project_id", request)
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// Get registry name
-		registryName, err := s.getMCPFieldValue("registry_name", request)
+		// Get container app name
+		containerAppName, err := s.getMCPFieldValue("containerapp_name", request)
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
+		if err := domain.ValidateContainerAppName(containerAppName); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
 
-		// Get is_public flag
-		isPublicStr, err := s.getMCPFieldValue("is_public", request)
+		// Get manifest settings
+		format, err := s.getMCPFieldValue("manifest_format", request)
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// Convert is_public to boolean
-		var isPublic bool
-		if isPublicStr == "true" || isPublicStr == "1" {
-			isPublic = true
-		} else if isPublicStr == "false" || isPublicStr == "0" {
-			isPublic = false
-		} else {
-			return mcp.NewToolResultError("is_public must be 'true' or 'false'"), nil
+		outputPath, err := s.getMCPFieldValue("output_path", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		overwriteStr, err := s.getMCPFieldValue("overwrite", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		overwrite, err := parseBoolField("overwrite", overwriteStr)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
 
 		credentials := domain.Credentials{
@@ -606,17 +1073,1072 @@ func (s *MCPServer) RegisterCreateDockerRegistryTool(server *server.MCPServer) {
 		}
 
 		// Call the service
-		dockerRegistry, err := s.dockerRegistryService.CreateDockerRegistry(projectID, registryName, isPublic, credentials)
+		manifest, err := s.containerAppsService.ExportContainerApp(projectID, containerAppName, strings.ToLower(format), outputPath, overwrite, credentials)
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// Convert to JSON for output
-		result, err := json.MarshalIndent(dockerRegistry, "", "  ")
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to format result: %v", err)), nil
+		if outputPath != "" {
+			return mcp.NewToolResultText(fmt.Sprintf("Successfully exported Container App %s to %s\n%s", containerAppName, outputPath, manifest)), nil
 		}
+		return mcp.NewToolResultText(manifest), nil
+	})
+}
 
-		return mcp.NewToolResultText(fmt.Sprintf("Successfully created Docker Registry: %s\n%s", registryName, string(result))), nil
+// RegisterDeleteContainerAppTool registers the delete container app tool with the MCP server
+func (s *MCPServer) RegisterDeleteContainerAppTool(server *server.MCPServer) {
+	// Prepare tool options including description and fields
+	toolOptions := s.getMCPFieldsOptions(
+		"Delete a Container App from Cloud.ru. WARNING: This action cannot be undone! confirm_containerapp_name must repeat the exact name of the Container App. Protected Container Apps can't be deleted",
+		"project_id",
+		"containerapp_name",
+		"confirm_containerapp_name",
+	)
+	deleteContainerAppTool := mcp.NewTool("cloudru_delete_containerapp", toolOptions...)
+
+	server.AddTool(deleteContainerAppTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get project ID
+		projectID, err := s.getMCPFieldValue("project_id", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get container app name
+		containerAppName, err := s.getMCPFieldValue("containerapp_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if err := domain.ValidateContainerAppName(containerAppName); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Require the exact name to be repeated, so a hallucinated or mistyped name doesn't delete the wrong app
+		confirmContainerAppName, err := s.getMCPFieldValue("confirm_containerapp_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if confirmContainerAppName != containerAppName {
+			return mcp.NewToolResultError(fmt.Sprintf("confirm_containerapp_name %q does not match the Container App to delete %q, nothing was deleted", confirmContainerAppName, containerAppName)), nil
+		}
+
+		credentials := domain.Credentials{
+			KeyID:     s.cfg.KeyID,
+			KeySecret: s.cfg.KeySecret,
+		}
+
+		// Call the service
+		operationID, err := s.containerAppsService.DeleteContainerApp(projectID, containerAppName, credentials)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Successfully deleted Container App: %s%s", containerAppName, formatOperationID(operationID))), nil
+	})
+}
+
+// RegisterStartContainerAppTool registers the start container app tool with the MCP server
+func (s *MCPServer) RegisterStartContainerAppTool(server *server.MCPServer) {
+	// Prepare tool options including description and fields
+	toolOptions := s.getMCPFieldsOptions(
+		"Start a Container App in Cloud.ru",
+		"project_id",
+		"containerapp_name",
+	)
+	startContainerAppTool := mcp.NewTool("cloudru_start_containerapp", toolOptions...)
+
+	server.AddTool(startContainerAppTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get project ID
+		projectID, err := s.getMCPFieldValue("project_id", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get container app name
+		containerAppName, err := s.getMCPFieldValue("containerapp_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if err := domain.ValidateContainerAppName(containerAppName); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		credentials := domain.Credentials{
+			KeyID:     s.cfg.KeyID,
+			KeySecret: s.cfg.KeySecret,
+		}
+
+		// Call the service
+		operationID, err := s.containerAppsService.StartContainerApp(projectID, containerAppName, credentials)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Successfully started Container App: %s%s", containerAppName, formatOperationID(operationID))), nil
+	})
+}
+
+// RegisterStopContainerAppTool registers the stop container app tool with the MCP server
+func (s *MCPServer) RegisterStopContainerAppTool(server *server.MCPServer) {
+	// Prepare tool options including description and fields
+	toolOptions := s.getMCPFieldsOptions(
+		"Stop a Container App in Cloud.ru",
+		"project_id",
+		"containerapp_name",
+	)
+	stopContainerAppTool := mcp.NewTool("cloudru_stop_containerapp", toolOptions...)
+
+	server.AddTool(stopContainerAppTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get project ID
+		projectID, err := s.getMCPFieldValue("project_id", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get container app name
+		containerAppName, err := s.getMCPFieldValue("containerapp_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if err := domain.ValidateContainerAppName(containerAppName); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		credentials := domain.Credentials{
+			KeyID:     s.cfg.KeyID,
+			KeySecret: s.cfg.KeySecret,
+		}
+
+		// Call the service
+		operationID, err := s.containerAppsService.StopContainerApp(projectID, containerAppName, credentials)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Successfully stopped Container App: %s%s", containerAppName, formatOperationID(operationID))), nil
+	})
+}
+
+// RegisterRestartContainerAppTool registers the restart container app tool with the MCP server
+func (s *MCPServer) RegisterRestartContainerAppTool(server *server.MCPServer) {
+	// Prepare tool options including description and fields
+	toolOptions := s.getMCPFieldsOptions(
+		"Restart a Container App in Cloud.ru: stop it, wait until it is stopped, start it and wait until it is running. Protected Container Apps can't be restarted",
+		"project_id",
+		"containerapp_name",
+	)
+	restartContainerAppTool := mcp.NewTool("cloudru_restart_containerapp", toolOptions...)
+
+	server.AddTool(restartContainerAppTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get project ID
+		projectID, err := s.getMCPFieldValue("project_id", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get container app name
+		containerAppName, err := s.getMCPFieldValue("containerapp_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if err := domain.ValidateContainerAppName(containerAppName); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		credentials := domain.Credentials{
+			KeyID:     s.cfg.KeyID,
+			KeySecret: s.cfg.KeySecret,
+		}
+
+		// Call the service
+		restartResult, err := s.containerAppsService.RestartContainerApp(ctx, projectID, containerAppName, credentials)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf(
+			"Successfully restarted Container App: %s\nStop phase took: %s\nStart phase took: %s",
+			containerAppName,
+			restartResult.StopDuration.Round(time.Second),
+			restartResult.StartDuration.Round(time.Second),
+		)), nil
+	})
+}
+
+// RegisterBulkContainerAppsTool registers the bulk container apps tool with the MCP server
+func (s *MCPServer) RegisterBulkContainerAppsTool(server *server.MCPServer) {
+	// Prepare tool options including description and fields
+	toolOptions := s.getMCPFieldsOptions(
+		"Start, stop or delete every Container App in Cloud.ru matching a selector (name pattern or regex, status, image, visibility, labels). Runs as a dry-run preview unless dry_run is false. WARNING: bulk delete cannot be undone! Stop and delete require name_pattern, name_regex or label_selector. Bulk delete requires confirm_containerapp_names to list exactly the Container Apps from the dry run. Protected Container Apps are skipped with an error",
+		"project_id",
+		"bulk_action",
+		"name_pattern",
+		"name_regex",
+		"status",
+		"image_contains",
+		"visibility",
+		"label_selector",
+		"dry_run",
+		"concurrency",
+		"confirm_containerapp_names",
+	)
+	bulkContainerAppsTool := mcp.NewTool("cloudru_bulk_containerapps", toolOptions...)
+
+	server.AddTool(bulkContainerAppsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get project ID
+		projectID, err := s.getMCPFieldValue("project_id", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get bulk action settings
+		action, err := s.getMCPFieldValue("bulk_action", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		filter, err := s.getContainerAppFilter(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		dryRunStr, err := s.getMCPFieldValue("dry_run", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		dryRun, err := parseBoolField("dry_run", dryRunStr)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		concurrencyStr, err := s.getMCPFieldValue("concurrency", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		concurrency, err := strconv.Atoi(concurrencyStr)
+		if err != nil {
+			return mcp.NewToolResultError("concurrency must be a number"), nil
+		}
+
+		confirmNamesStr, err := s.getMCPFieldValue("confirm_containerapp_names", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		confirmNames := []string{}
+		for _, name := range strings.Split(confirmNamesStr, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				confirmNames = append(confirmNames, name)
+			}
+		}
+
+		credentials := domain.Credentials{
+			KeyID:     s.cfg.KeyID,
+			KeySecret: s.cfg.KeySecret,
+		}
+
+		// Call the service
+		bulkResult, err := s.containerAppsService.BulkContainerAppsAction(projectID, strings.ToLower(action), filter, dryRun, concurrency, confirmNames, credentials)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		return mcp.NewToolResultText(formatBulkResult(bulkResult)), nil
+	})
+}
+
+// formatBulkResult renders the outcome of a bulk action as a table with one row per Container App
+func formatBulkResult(bulkResult *domain.BulkResult) string {
+	if len(bulkResult.Items) == 0 {
+		return "No Container Apps match the selector"
+	}
+
+	var builder strings.Builder
+	if bulkResult.DryRun {
+		fmt.Fprintf(&builder, "Dry run: %d Container Apps would be affected by %s. Call again with dry_run=false to apply\n\n", len(bulkResult.Items), bulkResult.Action)
+	} else {
+		failed := bulkResult.Failed()
+		fmt.Fprintf(&builder, "Bulk %s finished: %d succeeded, %d failed\n\n", bulkResult.Action, len(bulkResult.Items)-failed, failed)
+	}
+
+	builder.WriteString("| Container App | Status before | Result |\n")
+	builder.WriteString("|---|---|---|\n")
+	for _, item := range bulkResult.Items {
+		outcome := "would " + bulkResult.Action
+		if !bulkResult.DryRun {
+			outcome = "ok"
+			if item.OperationID != "" {
+				outcome = fmt.Sprintf("ok (operation %s)", item.OperationID)
+			}
+			if item.Error != "" {
+				outcome = "failed: " + strings.ReplaceAll(item.Error, "\n", " ")
+			}
+		}
+		fmt.Fprintf(&builder, "| %s | %s | %s |\n", item.Name, item.Status, outcome)
+	}
+
+	return builder.String()
+}
+
+// formatOperationID tells how to follow the asynchronous platform operation, if the API reported one
+func formatOperationID(operationID string) string {
+	if operationID == "" {
+		return ""
+	}
+	return fmt.Sprintf("\nOperation ID: %s (check its progress with cloudru_get_operation)", operationID)
+}
+
+// RegisterGetOperationTool registers the get operation tool with the MCP server
+func (s *MCPServer) RegisterGetOperationTool(server *server.MCPServer) {
+	// Prepare tool options including description and fields
+	toolOptions := s.getMCPFieldsOptions(
+		"Get progress, errors and completion of an asynchronous Cloud.ru operation, such as creating, deleting, starting or stopping a Container App",
+		"operation_id",
+	)
+	getOperationTool := mcp.NewTool("cloudru_get_operation", toolOptions...)
+
+	server.AddTool(getOperationTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get operation ID
+		operationID, err := s.getMCPFieldValue("operation_id", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		credentials := domain.Credentials{
+			KeyID:     s.cfg.KeyID,
+			KeySecret: s.cfg.KeySecret,
+		}
+
+		// Call the service
+		operation, err := s.containerAppsService.GetOperation(operationID, credentials)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Convert to JSON for output
+		result, err := json.MarshalIndent(operation, "", "  ")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to format result: %v", err)), nil
+		}
+
+		status := operation.Status()
+		if status == domain.OperationStatusFailed {
+			status = fmt.Sprintf("%s: %s", status, operation.Error.Message)
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Operation %s status: %s\n%s", operationID, status, string(result))), nil
+	})
+}
+
+// RegisterListSchedulesTool registers the list schedules tool with the MCP server
+func (s *MCPServer) RegisterListSchedulesTool(server *server.MCPServer) {
+	// Prepare tool options including description and fields
+	toolOptions := s.getMCPFieldsOptions(
+		"List schedules that start and stop Container Apps, with their last and next runs",
+	)
+	listSchedulesTool := mcp.NewTool("cloudru_list_schedules", toolOptions...)
+
+	server.AddTool(listSchedulesTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Call the service
+		schedules, err := s.schedulerService.ListSchedules()
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		if len(schedules) == 0 {
+			return mcp.NewToolResultText("No schedules configured"), nil
+		}
+
+		// Convert to JSON for output
+		result, err := json.MarshalIndent(schedules, "", "  ")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to format result: %v", err)), nil
+		}
+
+		return mcp.NewToolResultText(string(result)), nil
+	})
+}
+
+// RegisterAddScheduleTool registers the add schedule tool with the MCP server
+func (s *MCPServer) RegisterAddScheduleTool(server *server.MCPServer) {
+	// Prepare tool options including description and fields
+	toolOptions := s.getMCPFieldsOptions(
+		"Add a schedule that starts and stops the Container Apps matching a selector, e.g. to stop dev apps overnight and on weekends. Rules are cron expressions evaluated in the timezone; stopped apps are started by start_cron, running apps are stopped by stop_cron",
+		"schedule_name",
+		"project_id",
+		"name_pattern",
+		"name_regex",
+		"image_contains",
+		"visibility",
+		"label_selector",
+		"start_cron",
+		"stop_cron",
+		"timezone",
+	)
+	addScheduleTool := mcp.NewTool("cloudru_add_schedule", toolOptions...)
+
+	server.AddTool(addScheduleTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		schedule := domain.Schedule{}
+		fields := []struct {
+			name  string
+			value *string
+		}{
+			{"schedule_name", &schedule.Name},
+			{"project_id", &schedule.ProjectID},
+			{"name_pattern", &schedule.Selector.NamePattern},
+			{"name_regex", &schedule.Selector.NameRegex},
+			{"image_contains", &schedule.Selector.ImageContains},
+			{"visibility", &schedule.Selector.Visibility},
+			{"label_selector", &schedule.Selector.Labels},
+			{"start_cron", &schedule.StartCron},
+			{"stop_cron", &schedule.StopCron},
+			{"timezone", &schedule.Timezone},
+		}
+		for _, field := range fields {
+			value, err := s.getMCPFieldValue(field.name, request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			*field.value = value
+		}
+
+		// Call the service
+		if err := s.schedulerService.AddSchedule(schedule); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Successfully added schedule: %s. It runs while this MCP server is running", schedule.Name)), nil
+	})
+}
+
+// RegisterRemoveScheduleTool registers the remove schedule tool with the MCP server
+func (s *MCPServer) RegisterRemoveScheduleTool(server *server.MCPServer) {
+	// Prepare tool options including description and fields
+	toolOptions := s.getMCPFieldsOptions(
+		"Remove a schedule that starts and stops Container Apps. The Container Apps are left in their current state",
+		"schedule_name",
+	)
+	removeScheduleTool := mcp.NewTool("cloudru_remove_schedule", toolOptions...)
+
+	server.AddTool(removeScheduleTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get schedule name
+		scheduleName, err := s.getMCPFieldValue("schedule_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Call the service
+		if err := s.schedulerService.RemoveSchedule(scheduleName); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Successfully removed schedule: %s", scheduleName)), nil
+	})
+}
+
+// RegisterListCustomDomainsTool registers the list custom domains tool with the MCP server
+func (s *MCPServer) RegisterListCustomDomainsTool(server *server.MCPServer) {
+	// Prepare tool options including description and fields
+	toolOptions := s.getMCPFieldsOptions(
+		"List the custom domains of a Container App in Cloud.ru with their verification and certificate status and the DNS records they need",
+		"project_id",
+		"containerapp_name",
+	)
+	listCustomDomainsTool := mcp.NewTool("cloudru_list_containerapp_domains", toolOptions...)
+
+	server.AddTool(listCustomDomainsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get project ID
+		projectID, err := s.getMCPFieldValue("project_id", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get container app name
+		containerAppName, err := s.getMCPFieldValue("containerapp_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if err := domain.ValidateContainerAppName(containerAppName); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		credentials := domain.Credentials{
+			KeyID:     s.cfg.KeyID,
+			KeySecret: s.cfg.KeySecret,
+		}
+
+		// Call the service
+		domains, err := s.containerAppsService.ListCustomDomains(projectID, containerAppName, credentials)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Convert to JSON for output
+		result, err := json.MarshalIndent(domains, "", "  ")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to format result: %v", err)), nil
+		}
+
+		return mcp.NewToolResultText(string(result)), nil
+	})
+}
+
+// RegisterAttachCustomDomainTool registers the attach custom domain tool with the MCP server
+func (s *MCPServer) RegisterAttachCustomDomainTool(server *server.MCPServer) {
+	// Prepare tool options including description and fields
+	toolOptions := s.getMCPFieldsOptions(
+		"Attach a custom domain to a publicly accessible Container App in Cloud.ru. Returns the DNS records to create at the DNS provider; the certificate is issued after the domain is verified. Check the progress with cloudru_list_containerapp_domains",
+		"project_id",
+		"containerapp_name",
+		"domain_name",
+	)
+	attachCustomDomainTool := mcp.NewTool("cloudru_attach_containerapp_domain", toolOptions...)
+
+	server.AddTool(attachCustomDomainTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get project ID
+		projectID, err := s.getMCPFieldValue("project_id", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get container app name
+		containerAppName, err := s.getMCPFieldValue("containerapp_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if err := domain.ValidateContainerAppName(containerAppName); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get domain name
+		domainName, err := s.getMCPFieldValue("domain_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if err := domain.ValidateDomainName(domainName); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		credentials := domain.Credentials{
+			KeyID:     s.cfg.KeyID,
+			KeySecret: s.cfg.KeySecret,
+		}
+
+		// Call the service
+		customDomain, err := s.containerAppsService.AttachCustomDomain(projectID, containerAppName, domainName, credentials)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Convert to JSON for output
+		result, err := json.MarshalIndent(customDomain, "", "  ")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to format result: %v", err)), nil
+		}
+
+		message := fmt.Sprintf("Successfully attached domain %s to Container App %s", customDomain.Name, containerAppName)
+		if !customDomain.IsReady() {
+			if len(customDomain.DNSRecords) > 0 {
+				message += ". Create the DNS records listed in dnsRecords, then the domain is verified and its certificate is issued"
+			} else {
+				message += ". The API returned no DNS records yet, check them later with cloudru_list_containerapp_domains or in the Cloud.ru console"
+			}
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("%s%s\n%s", message, formatOperationID(customDomain.OperationID), string(result))), nil
+	})
+}
+
+// RegisterDetachCustomDomainTool registers the detach custom domain tool with the MCP server
+func (s *MCPServer) RegisterDetachCustomDomainTool(server *server.MCPServer) {
+	// Prepare tool options including description and fields
+	toolOptions := s.getMCPFieldsOptions(
+		"Detach a custom domain from a Container App in Cloud.ru. The domain stops serving the app, the generated URI keeps working. confirm_domain_name must repeat the exact domain name. Domains of protected Container Apps can't be detached",
+		"project_id",
+		"containerapp_name",
+		"domain_name",
+		"confirm_domain_name",
+	)
+	detachCustomDomainTool := mcp.NewTool("cloudru_detach_containerapp_domain", toolOptions...)
+
+	server.AddTool(detachCustomDomainTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get project ID
+		projectID, err := s.getMCPFieldValue("project_id", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get container app name
+		containerAppName, err := s.getMCPFieldValue("containerapp_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if err := domain.ValidateContainerAppName(containerAppName); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get domain name
+		domainName, err := s.getMCPFieldValue("domain_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if err := domain.ValidateDomainName(domainName); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Require the exact domain to be repeated, so a production hostname isn't detached by mistake
+		confirmDomainName, err := s.getMCPFieldValue("confirm_domain_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if confirmDomainName != domainName {
+			return mcp.NewToolResultError(fmt.Sprintf("confirm_domain_name %q does not match the domain to detach %q, nothing was detached", confirmDomainName, domainName)), nil
+		}
+
+		credentials := domain.Credentials{
+			KeyID:     s.cfg.KeyID,
+			KeySecret: s.cfg.KeySecret,
+		}
+
+		// Call the service
+		operationID, err := s.containerAppsService.DetachCustomDomain(projectID, containerAppName, domainName, credentials)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Successfully detached domain %s from Container App %s%s", domainName, containerAppName, formatOperationID(operationID))), nil
+	})
+}
+
+// RegisterGetListDockerRegistriesTool registers the get list docker registries tool with the MCP server
+func (s *MCPServer) RegisterGetListDockerRegistriesTool(server *server.MCPServer) {
+	// Prepare tool options including description and fields
+	toolOptions := s.getMCPFieldsOptions(
+		"Get list of Docker Registries from Cloud.ru. Project ID can be set via PROJECT_ID environment variable and obtained from console.cloud.ru",
+		"project_id",
+	)
+	getListDockerRegistriesTool := mcp.NewTool("cloudru_get_list_docker_registries", toolOptions...)
+
+	server.AddTool(getListDockerRegistriesTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get project ID
+		projectID, err := s.getMCPFieldValue("project_id", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		credentials := domain.Credentials{
+			KeyID:     s.cfg.KeyID,
+			KeySecret: s.cfg.KeySecret,
+		}
+
+		// Call the service
+		dockerRegistries, err := s.dockerRegistryService.GetListDockerRegistries(projectID, credentials)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Convert to JSON for output
+		result, err := json.MarshalIndent(dockerRegistries, "", "  ")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to format result: %v", err)), nil
+		}
+
+		return mcp.NewToolResultText(string(result)), nil
+	})
+}
+
+// RegisterCreateDockerRegistryTool registers the create docker registry tool with the MCP server
+func (s *MCPServer) RegisterCreateDockerRegistryTool(server *server.MCPServer) {
+	// Prepare tool options including description and fields
+	toolOptions := s.getMCPFieldsOptions(
+		"Create a new Docker Registry in Cloud.ru",
+		"project_id",
+		"registry_name",
+		"is_public",
+	)
+	createDockerRegistryTool := mcp.NewTool("cloudru_create_docker_registry", toolOptions...)
+
+	server.AddTool(createDockerRegistryTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get project ID
+		projectID, err := s.getMCPFieldValue("project_id", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get registry name
+		registryName, err := s.getMCPFieldValue("registry_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if err := domain.ValidateRegistryName(registryName); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get is_public flag
+		isPublicStr, err := s.getMCPFieldValue("is_public", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Convert is_public to boolean
+		var isPublic bool
+		if isPublicStr == "true" || isPublicStr == "1" {
+			isPublic = true
+		} else if isPublicStr == "false" || isPublicStr == "0" {
+			isPublic = false
+		} else {
+			return mcp.NewToolResultError("is_public must be 'true' or 'false'"), nil
+		}
+
+		credentials := domain.Credentials{
+			KeyID:     s.cfg.KeyID,
+			KeySecret: s.cfg.KeySecret,
+		}
+
+		// Call the service
+		dockerRegistry, err := s.dockerRegistryService.CreateDockerRegistry(projectID, registryName, isPublic, credentials)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Convert to JSON for output
+		result, err := json.MarshalIndent(dockerRegistry, "", "  ")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to format result: %v", err)), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Successfully created Docker Registry: %s\n%s", registryName, string(result))), nil
+	})
+}
+
+// RegisterDeleteDockerRegistryTool registers the delete docker registry tool with the MCP server
+func (s *MCPServer) RegisterDeleteDockerRegistryTool(server *server.MCPServer) {
+	// Prepare tool options including description and fields
+	toolOptions := s.getMCPFieldsOptions(
+		"Delete a Docker Registry with all its images from Cloud.ru. WARNING: This action cannot be undone! confirm_registry_name must repeat the exact name of the registry. A registry with images used by running Container Apps is only deleted with force=true. Protected registries can't be deleted",
+		"project_id",
+		"registry_name",
+		"confirm_registry_name",
+		"force",
+	)
+	deleteDockerRegistryTool := mcp.NewTool("cloudru_delete_docker_registry", toolOptions...)
+
+	server.AddTool(deleteDockerRegistryTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get project ID
+		projectID, err := s.getMCPFieldValue("project_id", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get registry name
+		registryName, err := s.getMCPFieldValue("registry_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if err := domain.ValidateRegistryName(registryName); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Require the exact name to be repeated, so a hallucinated or mistyped name doesn't delete the wrong registry
+		confirmRegistryName, err := s.getMCPFieldValue("confirm_registry_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if confirmRegistryName != registryName {
+			return mcp.NewToolResultError(fmt.Sprintf("confirm_registry_name %q does not match the Docker Registry to delete %q, nothing was deleted", confirmRegistryName, registryName)), nil
+		}
+
+		forceStr, err := s.getMCPFieldValue("force", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		force, err := parseBoolField("force", forceStr)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		credentials := domain.Credentials{
+			KeyID:     s.cfg.KeyID,
+			KeySecret: s.cfg.KeySecret,
+		}
+
+		// Call the service
+		deleteResult, err := s.dockerRegistryService.DeleteDockerRegistry(projectID, registryName, force, credentials)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		message := fmt.Sprintf("Successfully deleted Docker Registry: %s", registryName)
+		if len(deleteResult.ReferencingContainerApps) > 0 {
+			message += fmt.Sprintf("\nWARNING: running Container Apps %s used images from it and can't pull them anymore", strings.Join(deleteResult.ReferencingContainerApps, ", "))
+		}
+		return mcp.NewToolResultText(message + formatOperationID(deleteResult.OperationID)), nil
+	})
+}
+
+// RegisterListRepositoriesTool registers the list repositories tool with the MCP server
+func (s *MCPServer) RegisterListRepositoriesTool(server *server.MCPServer) {
+	// Prepare tool options including description and fields
+	toolOptions := s.getMCPFieldsOptions(
+		"List the repositories stored in a Docker Registry in Cloud.ru with their number of tags and size",
+		"project_id",
+		"registry_name",
+	)
+	listRepositoriesTool := mcp.NewTool("cloudru_list_repositories", toolOptions...)
+
+	server.AddTool(listRepositoriesTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get project ID
+		projectID, err := s.getMCPFieldValue("project_id", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get registry name
+		registryName, err := s.getMCPFieldValue("registry_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if err := domain.ValidateRegistryName(registryName); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		credentials := domain.Credentials{
+			KeyID:     s.cfg.KeyID,
+			KeySecret: s.cfg.KeySecret,
+		}
+
+		// Call the service
+		repositories, err := s.dockerRegistryService.ListRepositories(projectID, registryName, credentials)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Convert to JSON for output
+		result, err := json.MarshalIndent(repositories, "", "  ")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to format result: %v", err)), nil
+		}
+
+		return mcp.NewToolResultText(string(result)), nil
+	})
+}
+
+// RegisterListImageTagsTool registers the list image tags tool with the MCP server
+func (s *MCPServer) RegisterListImageTagsTool(server *server.MCPServer) {
+	// Prepare tool options including description and fields
+	toolOptions := s.getMCPFieldsOptions(
+		"List the image tags of a repository in a Docker Registry in Cloud.ru with digest, size, push time and platforms, the most recently pushed first. The image field can be passed as is to cloudru_create_containerapp",
+		"project_id",
+		"registry_name",
+		"repository_name",
+	)
+	listImageTagsTool := mcp.NewTool("cloudru_list_image_tags", toolOptions...)
+
+	server.AddTool(listImageTagsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get project ID
+		projectID, err := s.getMCPFieldValue("project_id", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get registry name
+		registryName, err := s.getMCPFieldValue("registry_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if err := domain.ValidateRegistryName(registryName); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get repository name
+		repositoryName, err := s.getMCPFieldValue("repository_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		credentials := domain.Credentials{
+			KeyID:     s.cfg.KeyID,
+			KeySecret: s.cfg.KeySecret,
+		}
+
+		// Call the service
+		tags, err := s.dockerRegistryService.ListImageTags(projectID, registryName, repositoryName, credentials)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Convert to JSON for output
+		result, err := json.MarshalIndent(tags, "", "  ")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to format result: %v", err)), nil
+		}
+
+		return mcp.NewToolResultText(string(result)), nil
+	})
+}
+
+// RegisterDeleteImageTool registers the delete image tool with the MCP server
+func (s *MCPServer) RegisterDeleteImageTool(server *server.MCPServer) {
+	// Prepare tool options including description and fields
+	toolOptions := s.getMCPFieldsOptions(
+		"Delete an image tag, or an image digest with all its tags, from a repository of a Docker Registry in Cloud.ru. WARNING: This action cannot be undone! confirm_tag_or_digest must repeat the exact tag or digest. Images still used by a Container App of the project and images of protected registries are not deleted",
+		"project_id",
+		"registry_name",
+		"repository_name",
+		"tag_or_digest",
+		"confirm_tag_or_digest",
+	)
+	deleteImageTool := mcp.NewTool("cloudru_delete_image", toolOptions...)
+
+	server.AddTool(deleteImageTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get project ID
+		projectID, err := s.getMCPFieldValue("project_id", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get registry name
+		registryName, err := s.getMCPFieldValue("registry_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if err := domain.ValidateRegistryName(registryName); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get repository name
+		repositoryName, err := s.getMCPFieldValue("repository_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get the tag or digest, it must be repeated so a mistyped tag doesn't delete the wrong image
+		tagOrDigest, err := s.getMCPFieldValue("tag_or_digest", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		confirmTagOrDigest, err := s.getMCPFieldValue("confirm_tag_or_digest", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if confirmTagOrDigest != tagOrDigest {
+			return mcp.NewToolResultError(fmt.Sprintf("confirm_tag_or_digest %q does not match the image to delete %q, nothing was deleted", confirmTagOrDigest, tagOrDigest)), nil
+		}
+
+		credentials := domain.Credentials{
+			KeyID:     s.cfg.KeyID,
+			KeySecret: s.cfg.KeySecret,
+		}
+
+		// Call the service
+		deleteResult, err := s.dockerRegistryService.DeleteImage(projectID, registryName, repositoryName, tagOrDigest, credentials)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		message := fmt.Sprintf("Successfully deleted image: %s", deleteResult.Image)
+		if len(deleteResult.DeletedTags) > 0 {
+			message += fmt.Sprintf("\nDeleted tags: %s", strings.Join(deleteResult.DeletedTags, ", "))
+		}
+		return mcp.NewToolResultText(message + formatOperationID(deleteResult.OperationID)), nil
+	})
+}
+
+// RegisterApplyDockerRegistryRetentionTool registers the apply docker registry retention tool with the MCP server
+func (s *MCPServer) RegisterApplyDockerRegistryRetentionTool(server *server.MCPServer) {
+	// Prepare tool options including description and fields
+	toolOptions := s.getMCPFieldsOptions(
+		"Clean up a Docker Registry in Cloud.ru with retention rules: keep the last N tags, delete untagged images, delete tags older than X days, protect tag patterns. The matching images are deleted once, the rules are not stored in the registry. Runs as a preview of the images the policy would delete unless preview is false. WARNING: deleted images cannot be restored! Images used by Container Apps are only deleted with force=true. Protected registries are not cleaned up",
+		"project_id",
+		"registry_name",
+		"keep_last_tags",
+		"delete_untagged",
+		"delete_older_than_days",
+		"protected_tag_patterns",
+		"preview",
+		"force",
+	)
+	applyDockerRegistryRetentionTool := mcp.NewTool("cloudru_apply_docker_registry_retention", toolOptions...)
+
+	server.AddTool(applyDockerRegistryRetentionTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get project ID
+		projectID, err := s.getMCPFieldValue("project_id", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get registry name
+		registryName, err := s.getMCPFieldValue("registry_name", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if err := domain.ValidateRegistryName(registryName); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		retentionPolicy, err := s.getRetentionPolicy(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if retentionPolicy == nil {
+			return mcp.NewToolResultError("at least one retention rule is required: keep_last_tags, delete_untagged or delete_older_than_days"), nil
+		}
+
+		previewStr, err := s.getMCPFieldValue("preview", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		preview, err := parseBoolField("preview", previewStr)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		forceStr, err := s.getMCPFieldValue("force", request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		force, err := parseBoolField("force", forceStr)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		credentials := domain.Credentials{
+			KeyID:     s.cfg.KeyID,
+			KeySecret: s.cfg.KeySecret,
+		}
+
+		if preview {
+			retentionPreview, err := s.dockerRegistryService.PreviewRetentionPolicy(projectID, registryName, *retentionPolicy, credentials)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// Convert to JSON for output
+			result, err := json.MarshalIndent(retentionPreview, "", "  ")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to format result: %v", err)), nil
+			}
+
+			message := fmt.Sprintf("Preview: the retention policy would delete %d images (%d bytes) from Docker Registry %s. Nothing was deleted, call again with preview=false to delete them", len(retentionPreview.Candidates), retentionPreview.ReclaimedBytes, registryName)
+			if inUse := retentionPreview.InUse(); len(inUse) > 0 {
+				message += fmt.Sprintf(". %d of the images are used by Container Apps (see inUseBy), they are only deleted with force=true", len(inUse))
+			}
+			return mcp.NewToolResultText(fmt.Sprintf("%s\n%s", message, string(result))), nil
+		}
+
+		// Call the service
+		retentionResult, err := s.dockerRegistryService.ApplyRetentionPolicy(projectID, registryName, *retentionPolicy, force, credentials)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Convert to JSON for output
+		result, err := json.MarshalIndent(retentionResult, "", "  ")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to format result: %v", err)), nil
+		}
+
+		message := fmt.Sprintf("Deleted %d images from Docker Registry %s with the retention policy", len(retentionResult.Deleted), registryName)
+		if len(retentionResult.Errors) > 0 {
+			message += fmt.Sprintf(", %d errors (see errors)", len(retentionResult.Errors))
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("%s\n%s", message, string(result))), nil
 	})
 }
@@ -0,0 +1,71 @@
+package presentation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Nick1994209/cloudru-containerapps-mcp/internal/domain"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// getRetentionPolicy collects the retention rules passed to the tool, it returns nil if no rule was passed
+func (s *MCPServer) getRetentionPolicy(request mcp.CallToolRequest) (*domain.RetentionPolicy, error) {
+	policy := &domain.RetentionPolicy{}
+	passed := false
+
+	for _, field := range []struct {
+		name  string
+		value *int
+	}{
+		{"keep_last_tags", &policy.KeepLastTags},
+		{"delete_older_than_days", &policy.DeleteOlderThanDays},
+	} {
+		valueStr, err := s.getMCPFieldValue(field.name, request)
+		if err != nil {
+			return nil, err
+		}
+		if valueStr == "" {
+			continue
+		}
+		value, err := strconv.Atoi(valueStr)
+		if err != nil || value < 0 {
+			return nil, fmt.Errorf("%s must be a non-negative number", field.name)
+		}
+		*field.value = value
+		passed = true
+	}
+
+	deleteUntaggedStr, err := s.getMCPFieldValue("delete_untagged", request)
+	if err != nil {
+		return nil, err
+	}
+	if deleteUntaggedStr != "" {
+		deleteUntagged, err := parseBoolField("delete_untagged", deleteUntaggedStr)
+		if err != nil {
+			return nil, err
+		}
+		policy.DeleteUntagged = deleteUntagged
+		passed = true
+	}
+
+	protectedStr, err := s.getMCPFieldValue("protected_tag_patterns", request)
+	if err != nil {
+		return nil, err
+	}
+	for _, pattern := range strings.Split(protectedStr, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			policy.ProtectedTagPatterns = append(policy.ProtectedTagPatterns, pattern)
+			passed = true
+		}
+	}
+
+	if !passed {
+		return nil, nil
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
@@ -0,0 +1,89 @@
+package presentation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parseStringListField converts a tool argument into a list of strings.
+// The value can be either a JSON array of strings or a single string split into words the way a POSIX shell does.
+func parseStringListField(field string, value string) ([]string, error) {
+	if strings.HasPrefix(strings.TrimSpace(value), "[") {
+		words := []string{}
+		if err := parseJSONField(field, value, &words); err != nil {
+			return nil, err
+		}
+		return words, nil
+	}
+
+	words, err := splitShellWords(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", field, err)
+	}
+	return words, nil
+}
+
+// splitShellWords splits a command line into words honoring single quotes, double quotes and backslash escapes
+func splitShellWords(line string) ([]string, error) {
+	words := []string{}
+
+	var word strings.Builder
+	inWord := false
+	inSingleQuotes := false
+	inDoubleQuotes := false
+	escaped := false
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			// Inside double quotes a backslash only escapes characters that are special there
+			if inDoubleQuotes && r != '"' && r != '\\' && r != '$' && r != '`' {
+				word.WriteRune('\\')
+			}
+			word.WriteRune(r)
+			escaped = false
+		case inSingleQuotes:
+			if r == '\'' {
+				inSingleQuotes = false
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\\':
+			escaped = true
+			inWord = true
+		case inDoubleQuotes:
+			if r == '"' {
+				inDoubleQuotes = false
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\'':
+			inSingleQuotes = true
+			inWord = true
+		case r == '"':
+			inDoubleQuotes = true
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if escaped {
+		return nil, fmt.Errorf("unexpected backslash at the end of %q", line)
+	}
+	if inSingleQuotes || inDoubleQuotes {
+		return nil, fmt.Errorf("unterminated quote in %q", line)
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+
+	return words, nil
+}